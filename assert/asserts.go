@@ -24,3 +24,9 @@ func ResponseWithStatus(t *testing.T, w *httptest.ResponseRecorder, expectedStat
 		t.Fatalf("\nExpected: %d\nActual:%d\n", expectedStatusCode, w.Result().StatusCode)
 	}
 }
+
+func ResponseWithHeader(t *testing.T, w *httptest.ResponseRecorder, key string, expectedValue string) {
+	if w.Result().Header.Get(key) != expectedValue {
+		t.Fatalf("\nExpected %s: %s\nActual:%s\n", key, expectedValue, w.Result().Header.Get(key))
+	}
+}
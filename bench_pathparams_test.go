@@ -66,3 +66,20 @@ func BenchmarkWithPathParamTwentyRequests(b *testing.B) {
 		rtr.ServeHTTP(w, r)
 	}
 }
+
+func BenchmarkWithPathParamHttpHandler(b *testing.B) {
+	rtr := New()
+	rtr.HandleFunc(http.MethodGet, "/repos/:owner/:repo/pulls/:number/merge", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/repos/shyamz-22/oidc/pulls/44/merge", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rtr.ServeHTTP(w, r)
+	}
+}
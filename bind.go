@@ -0,0 +1,435 @@
+package router
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BindErrors lists a ParamError for each field that could not be bound.
+type BindErrors []*ParamError
+
+func (errs BindErrors) Error() string {
+	messages := make([]string, len(errs))
+
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Bind fills the fields of the struct dst points to that are tagged with the
+// name of a path param:
+//
+//	var dst struct {
+//		Owner string `path:"owner"`
+//		ID    int64  `path:"id" validate:"min=1"`
+//	}
+//
+// Fields may be strings, bools, numbers, time.Time, parsed in the layout of
+// a `layout` tag or RFC 3339, implementations of encoding.TextUnmarshaler
+// such as UUID, and pointers to those. Path params are required. The
+// `validate` tag checks bound values:
+//
+//	required    the param has to be present, for query params
+//	min=n       numbers at least n, strings and lists at least n long
+//	max=n       numbers at most n, strings and lists at most n long
+//	oneof=a b   the value is one of the listed ones
+//
+// All fields are bound, the error is BindErrors listing every failed field.
+// Tags and field types are checked once per type; if they are malformed,
+// the error says so and nothing is bound.
+func (params PathParams) Bind(dst interface{}) error {
+	return bind(dst, params, nil)
+}
+
+// BindRequest is Bind that also fills fields tagged with the name of a query
+// param, `query:"page"`. Query params are optional unless validated as
+// required, and slice fields take all values of a repeated query param.
+func (params PathParams) BindRequest(request *http.Request, dst interface{}) error {
+	return bind(dst, params, request.URL.Query())
+}
+
+// BindPath binds the path params the router stored in the context of
+// request and its query params to dst. It is meant for http.Handler routes,
+// as the router does not store the params of HandlerFuncWithParam routes,
+// see ParamsFromContext. See PathParams.BindRequest.
+func BindPath(request *http.Request, dst interface{}) error {
+	return ParamsFromContext(request.Context()).BindRequest(request, dst)
+}
+
+func bind(dst interface{}, params PathParams, query url.Values) error {
+	v := reflect.ValueOf(dst)
+
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("router: cannot bind to %T, destination must be a pointer to a struct", dst)
+	}
+
+	b, err := bindingOf(v.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	var errs BindErrors
+
+	for _, f := range b.fields {
+		var values []string
+
+		if f.in == "path" {
+			if value, ok := params.Get(f.name); ok {
+				values = []string{value}
+			}
+		} else {
+			values = query[f.name]
+		}
+
+		if err := f.bind(v.Elem().FieldByIndex(f.index), values); err != nil {
+			err.In, err.Name = f.in, f.name
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// binding lists the tagged fields of a struct type.
+type binding struct {
+	fields []fieldBinding
+	err    error
+}
+
+// fieldBinding binds a path or query param to a struct field.
+type fieldBinding struct {
+	field    reflect.StructField
+	index    []int
+	in       string
+	name     string
+	required bool
+	rules    []rule
+}
+
+// rule is a parsed rule of a `validate` tag.
+type rule struct {
+	name    string
+	arg     string
+	bound   float64
+	options []string
+}
+
+var bindings sync.Map // reflect.Type → *binding
+
+// bindingOf returns the binding of struct type t, checking its tags and
+// field types once, so that malformed tags are reported as errors instead
+// of failing requests later.
+func bindingOf(t reflect.Type) (*binding, error) {
+	if b, ok := bindings.Load(t); ok {
+		return b.(*binding), b.(*binding).err
+	}
+
+	b := &binding{}
+	b.err = b.add(t, nil)
+	if b.err != nil {
+		b.err = fmt.Errorf("router: cannot bind to %s: %w", t, b.err)
+	}
+
+	actual, _ := bindings.LoadOrStore(t, b)
+
+	return actual.(*binding), actual.(*binding).err
+}
+
+func (b *binding) add(t reflect.Type, index []int) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := b.add(field.Type, fieldIndex); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		f := fieldBinding{field: field, index: fieldIndex}
+
+		if f.name = field.Tag.Get("path"); f.name != "" {
+			f.in, f.required = "path", true
+		} else if f.name = field.Tag.Get("query"); f.name != "" {
+			f.in = "query"
+		} else {
+			continue
+		}
+
+		elem := field.Type
+		if elem.Kind() == reflect.Slice && !reflect.PtrTo(elem).Implements(textUnmarshalerType) {
+			elem = elem.Elem()
+		}
+
+		if !bindable(elem) {
+			return fmt.Errorf("field %s: type %s is not supported", field.Name, field.Type)
+		}
+
+		for _, text := range strings.Split(field.Tag.Get("validate"), ",") {
+			r := rule{}
+			r.name, r.arg, _ = strings.Cut(text, "=")
+
+			switch r.name {
+			case "":
+				continue
+			case "required":
+				f.required = true
+				continue
+			case "min", "max":
+				bound, err := strconv.ParseFloat(r.arg, 64)
+				if err != nil {
+					return fmt.Errorf("field %s: bound %s is not a number", field.Name, r.arg)
+				}
+				if !bounded(field.Type) {
+					return fmt.Errorf("field %s: type %s has no bounds", field.Name, field.Type)
+				}
+				r.bound = bound
+			case "oneof":
+				r.options = strings.Fields(r.arg)
+			default:
+				return fmt.Errorf("field %s: rule %s is not supported", field.Name, r.name)
+			}
+
+			f.rules = append(f.rules, r)
+		}
+
+		b.fields = append(b.fields, f)
+	}
+
+	return nil
+}
+
+// bindable reports whether a param can be set to a field of type t.
+func bindable(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// bounded reports whether min and max apply to fields of type t.
+func bounded(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Slice,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+func (f *fieldBinding) bind(fv reflect.Value, values []string) *ParamError {
+	if len(values) == 0 {
+		if f.required {
+			return &ParamError{Err: ErrParamNotFound}
+		}
+
+		return nil
+	}
+
+	if fv.Kind() == reflect.Slice && !isTextUnmarshaler(fv) {
+		slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+
+		for i, value := range values {
+			if err := setValue(slice.Index(i), f.field, value); err != nil {
+				return err
+			}
+		}
+
+		fv.Set(slice)
+	} else if err := setValue(fv, f.field, values[len(values)-1]); err != nil {
+		return err
+	}
+
+	return validate(fv, values, f.rules)
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func setValue(fv reflect.Value, field reflect.StructField, value string) *ParamError {
+	if fv.Kind() == reflect.Ptr {
+		ptr := reflect.New(fv.Type().Elem())
+		if err := setValue(ptr.Elem(), field, value); err != nil {
+			return err
+		}
+
+		fv.Set(ptr)
+
+		return nil
+	}
+
+	var err error
+
+	switch {
+	case fv.Type() == timeType:
+		layout := field.Tag.Get("layout")
+		if layout == "" {
+			layout = time.RFC3339
+		}
+
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			fv.Set(reflect.ValueOf(t))
+		}
+
+	case isTextUnmarshaler(fv):
+		err = fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+
+	default:
+		switch fv.Kind() {
+		case reflect.String:
+			fv.SetString(value)
+
+		case reflect.Bool:
+			var b bool
+			if b, err = strconv.ParseBool(value); err == nil {
+				fv.SetBool(b)
+			}
+
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			var i int64
+			if i, err = strconv.ParseInt(value, 10, fv.Type().Bits()); err == nil {
+				fv.SetInt(i)
+			}
+
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			var u uint64
+			if u, err = strconv.ParseUint(value, 10, fv.Type().Bits()); err == nil {
+				fv.SetUint(u)
+			}
+
+		case reflect.Float32, reflect.Float64:
+			var f float64
+			if f, err = strconv.ParseFloat(value, fv.Type().Bits()); err == nil {
+				fv.SetFloat(f)
+			}
+
+		default:
+			err = fmt.Errorf("type %s is not supported", fv.Type())
+		}
+	}
+
+	if err != nil {
+		return &ParamError{Value: value, Type: typeName(fv.Type()), Err: err}
+	}
+
+	return nil
+}
+
+func isTextUnmarshaler(fv reflect.Value) bool {
+	return reflect.PtrTo(fv.Type()).Implements(textUnmarshalerType)
+}
+
+func typeName(t reflect.Type) string {
+	switch t {
+	case timeType:
+		return "time"
+	case reflect.TypeOf(UUID{}):
+		return "uuid"
+	}
+
+	if t.Kind() == reflect.Struct || t.Kind() == reflect.Array {
+		return t.String()
+	}
+
+	return t.Kind().String()
+}
+
+// validate checks the bound value of fv against rules.
+func validate(fv reflect.Value, values []string, rules []rule) *ParamError {
+	for _, r := range rules {
+		var err error
+
+		switch r.name {
+		case "min":
+			err = checkBound(fv, r, func(n, bound float64) bool { return n >= bound }, "at least")
+		case "max":
+			err = checkBound(fv, r, func(n, bound float64) bool { return n <= bound }, "at most")
+		case "oneof":
+			for _, value := range values {
+				if !contains(r.options, value) {
+					err = fmt.Errorf("must be one of %s", strings.Join(r.options, ", "))
+				}
+			}
+		}
+
+		if err != nil {
+			return &ParamError{Value: strings.Join(values, ","), Err: err}
+		}
+	}
+
+	return nil
+}
+
+func checkBound(fv reflect.Value, r rule, ok func(n, bound float64) bool, description string) error {
+	for fv.Kind() == reflect.Ptr {
+		fv = fv.Elem()
+	}
+
+	var n float64
+
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(fv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(fv.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = fv.Float()
+	case reflect.String:
+		if !ok(float64(len(fv.String())), r.bound) {
+			return fmt.Errorf("must have %s %s characters", description, r.arg)
+		}
+		return nil
+	case reflect.Slice:
+		if !ok(float64(fv.Len()), r.bound) {
+			return fmt.Errorf("must have %s %s values", description, r.arg)
+		}
+		return nil
+	}
+
+	if !ok(n, r.bound) {
+		return fmt.Errorf("must be %s %s", description, r.arg)
+	}
+
+	return nil
+}
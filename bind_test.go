@@ -0,0 +1,148 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type pageQuery struct {
+	Page  int    `query:"page" validate:"min=1"`
+	Order string `query:"order" validate:"oneof=asc desc"`
+}
+
+type pullsRequest struct {
+	pageQuery
+	Owner  string    `path:"owner" validate:"max=10"`
+	Number uint      `path:"number"`
+	ID     *UUID     `query:"id"`
+	Labels []string  `query:"label"`
+	Since  time.Time `query:"since" layout:"2006-01-02"`
+	Draft  bool      `query:"draft" validate:"required"`
+	ignore string
+}
+
+func TestBindParams(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddGet("/repos/:owner/pulls/:number", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		req := pullsRequest{pageQuery: pageQuery{Page: 1, Order: "asc"}}
+
+		if err := params.BindRequest(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprintf(w, "%s#%d page %d %s %v %s %v %s",
+			req.Owner, req.Number, req.Page, req.Order, req.Labels, req.Since.Format(time.DateOnly), req.Draft, req.ID)
+	})
+
+	rtr.HandleFunc(http.MethodGet, "/repos/:owner", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Owner string `path:"owner"`
+			Page  int    `query:"page"`
+		}
+
+		err := BindPath(r, &req)
+		fmt.Fprintf(w, "%s %d %v", req.Owner, req.Page, err)
+	})
+
+	t.Run("binds path and query params", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/repos/gopher/pulls/7?page=2&label=bug&label=ui&since=2024-03-01&draft=true&id=123e4567-e89b-12d3-a456-426614174000", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "gopher#7 page 2 asc [bug ui] 2024-03-01 true 123e4567-e89b-12d3-a456-426614174000")
+	})
+
+	t.Run("aggregates errors per field", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/repos/gophers-and-friends/pulls/x?page=0&order=up&id=1", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusBadRequest, `query param "page": must be at least 1; `+
+			`query param "order": must be one of asc, desc; `+
+			`path param "owner": must have at most 10 characters; `+
+			`path param "number": "x" is not a valid uint; `+
+			`query param "id": "1" is not a valid uuid; `+
+			`query param "draft": not found`+"\n")
+	})
+
+	t.Run("binds params of http.Handler routes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/repos/gopher?page=3", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "gopher 3 <nil>")
+	})
+
+	t.Run("exposes field errors", func(t *testing.T) {
+		var req struct {
+			ID int `path:"id"`
+		}
+
+		err := PathParams{{Key: "id", Value: "abc"}}.Bind(&req)
+
+		var errs BindErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Name != "id" || errs[0].In != "path" {
+			t.Errorf("Unexpected error %#v", err)
+		}
+	})
+
+	t.Run("binds params in http.Handler routes", func(t *testing.T) {
+		rtr := New()
+		rtr.HandleFunc(http.MethodGet, "/users/:id", func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				ID int `path:"id"`
+			}
+
+			err := BindPath(r, &req)
+			fmt.Fprintf(w, "%d %v", req.ID, err)
+		})
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/users/42", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "42 <nil>")
+	})
+}
+
+func TestBindParamsWithMalformedTags(t *testing.T) {
+	t.Parallel()
+	params := PathParams{{Key: "id", Value: "1"}}
+
+	for _, tc := range []struct {
+		dst      interface{}
+		expected string
+	}{
+		{&struct {
+			ID int `path:"id" validate:"positive"`
+		}{}, "field ID: rule positive is not supported"},
+		{&struct {
+			ID int `path:"id" validate:"min=one"`
+		}{}, "field ID: bound one is not a number"},
+		{&struct {
+			ID bool `path:"id" validate:"max=1"`
+		}{}, "field ID: type bool has no bounds"},
+		{&struct {
+			ID map[string]int `path:"id"`
+		}{}, "field ID: type map[string]int is not supported"},
+		{struct{}{}, "router: cannot bind to struct {}, destination must be a pointer to a struct"},
+	} {
+		err := params.Bind(tc.dst)
+		if err == nil || !strings.HasPrefix(err.Error(), "router: cannot bind to ") || !strings.HasSuffix(err.Error(), tc.expected) {
+			t.Errorf("\nExpected: %s\nActual:   %v", tc.expected, err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Command routediff compares two versions of a route table and reports the
+// changes breaking clients, to catch them in release review:
+//
+//	git show v1.2.0:routes.yaml > /tmp/routes.yaml
+//	routediff /tmp/routes.yaml routes.yaml
+//
+// The route tables are Go source registering routes, fixture tables such as
+// fixture.Routes, JSON or YAML files, or router.Router.Routes encoded as
+// JSON, see package routetable. Breaking changes are removed routes, routes
+// serving another method, added or changed param constraints and routes
+// shadowed by new ones. Renamed params, dropped constraints and added routes
+// are listed as other changes.
+//
+// The exit status is 1 if there are breaking changes, 2 if the tables cannot
+// be read.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/shyamz-22/router/routetable"
+)
+
+func main() {
+	var (
+		table = flag.String("table", "", "read the fixture tables of Go `variable` only")
+		all   = flag.Bool("all", true, "list changes that are not breaking, too")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: routediff [flags] old new\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	breaking, err := run(os.Stdout, flag.Arg(0), flag.Arg(1), *table, *all)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "routediff:", err)
+		os.Exit(2)
+	}
+
+	if breaking {
+		os.Exit(1)
+	}
+}
+
+// run prints the changes from the old to the new table and tells if any of
+// them is breaking.
+func run(w io.Writer, oldFile, newFile, table string, all bool) (bool, error) {
+	old, err := routetable.LoadTable(oldFile, table)
+	if err != nil {
+		return false, err
+	}
+
+	new, err := routetable.LoadTable(newFile, table)
+	if err != nil {
+		return false, err
+	}
+
+	var breaking, other []routetable.Change
+
+	for _, change := range routetable.Diff(old, new) {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		} else {
+			other = append(other, change)
+		}
+	}
+
+	list := func(title string, changes []routetable.Change) {
+		fmt.Fprintln(w, title)
+		for _, change := range changes {
+			fmt.Fprintf(w, "\t%s (%s)\n", change, position(change))
+		}
+	}
+
+	if len(breaking) > 0 {
+		list("breaking changes:", breaking)
+	} else {
+		fmt.Fprintln(w, "no breaking changes")
+	}
+
+	if all && len(other) > 0 {
+		list("other changes:", other)
+	}
+
+	return len(breaking) > 0, nil
+}
+
+// position returns where the change is made, in the new table unless the
+// route was removed.
+func position(change routetable.Change) string {
+	if change.Kind == routetable.Removed {
+		return change.Old.Position()
+	}
+
+	return change.New.Position()
+}
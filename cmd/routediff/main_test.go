@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lists breaking and other changes", func(t *testing.T) {
+		var out strings.Builder
+
+		breaking, err := run(&out, "testdata/old.yaml", "testdata/new.yaml", "", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `breaking changes:
+	constraint changed GET /pulls/:number: adds [0-9]+ to :number (testdata/new.yaml:11)
+	method changed PUT /items/:id: now PATCH /items/:id (testdata/new.yaml:9)
+	method changed DELETE /feeds: now GET /feeds (testdata/new.yaml:15)
+	shadowed GET /users/:id: some of its requests now match GET /users/me (testdata/new.yaml:7)
+other changes:
+	params renamed GET /repos/:owner/:repo: :owner is now :user, :repo is now :name (testdata/new.yaml:3)
+	constraint changed GET /files/:id:[0-9]+: drops [0-9]+ of :id (testdata/new.yaml:13)
+	added GET /users/me (testdata/new.yaml:7)
+`
+		if !breaking || out.String() != expected {
+			t.Errorf("\nExpected:\n%s\nActual:\n%s", expected, out.String())
+		}
+	})
+
+	t.Run("compares fixture tables", func(t *testing.T) {
+		var out strings.Builder
+
+		breaking, err := run(&out, "../../fixture/fixtures.go", "../../fixture/fixtures.go", "Routes", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if breaking || out.String() != "no breaking changes\n" {
+			t.Errorf("Unexpected output\n%s", out.String())
+		}
+	})
+
+	t.Run("reads encoded routes of a router", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir+"/old.json", `[{"Method": "GET", "Path": "/pings/:id", "Meta": null}]`)
+		writeFile(t, dir+"/new.json", `[{"Method": "GET", "Path": "/pings", "Meta": null}]`)
+
+		var out strings.Builder
+
+		breaking, err := run(&out, dir+"/old.json", dir+"/new.json", "", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := "breaking changes:\n\tremoved GET /pings/:id (" + dir + "/old.json:1)\n"
+		if !breaking || out.String() != expected {
+			t.Errorf("\nExpected:\n%s\nActual:\n%s", expected, out.String())
+		}
+	})
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
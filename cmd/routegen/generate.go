@@ -0,0 +1,377 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/shyamz-22/router/routetable"
+)
+
+type options struct {
+	pkg    string
+	source string
+	client bool
+}
+
+// path is a route path with the Go names generated for it.
+type path struct {
+	pattern string
+	name    string
+	params  []param
+	// url is the Go expression building the URL from the params.
+	url string
+	// imports are the packages url uses.
+	imports []string
+}
+
+type param struct {
+	name  string // in the route
+	ident string // of the Go argument
+	typ   string
+}
+
+// generate returns the formatted Go code for routes.
+func generate(routes []routetable.Route, opts options) ([]byte, error) {
+	paths := map[string]*path{}
+	names := map[string]string{}
+
+	var ordered []*path
+
+	for _, route := range routes {
+		if paths[route.Path] != nil {
+			continue
+		}
+
+		p := newPath(route.Path)
+
+		if other, ok := names[p.name]; ok {
+			return nil, fmt.Errorf("paths %q and %q both are named %s", other, route.Path, p.name)
+		}
+
+		names[p.name] = route.Path
+		paths[route.Path] = p
+		ordered = append(ordered, p)
+	}
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by routegen from %s. DO NOT EDIT.\n\n", filepath.Base(opts.source))
+	fmt.Fprintf(&b, "package %s\n\n", opts.pkg)
+
+	var body bytes.Buffer
+	imports := map[string]bool{}
+	writePaths(&body, ordered, imports)
+
+	if opts.client {
+		if err := writeClient(&body, routes, paths, imports); err != nil {
+			return nil, err
+		}
+	}
+
+	b.WriteString("import (\n")
+	for _, pkg := range sortedKeys(imports) {
+		fmt.Fprintf(&b, "\t%q\n", pkg)
+	}
+	b.WriteString(")\n\n")
+	b.Write(body.Bytes())
+
+	return format.Source(b.Bytes())
+}
+
+// writePaths writes the patterns and URL builders of paths, adding the
+// packages they use to imports.
+func writePaths(b *bytes.Buffer, paths []*path, imports map[string]bool) {
+	b.WriteString("// Route patterns.\nconst (\n")
+	for _, p := range paths {
+		fmt.Fprintf(b, "\tPath%s = %q\n", p.name, p.pattern)
+	}
+	b.WriteString(")\n")
+
+	rest := false
+
+	for _, p := range paths {
+		args := make([]string, len(p.params))
+		for i, prm := range p.params {
+			args[i] = prm.ident + " " + prm.typ
+		}
+
+		fmt.Fprintf(b, "\n// URL%s returns the path of %s.\n", p.name, p.pattern)
+		fmt.Fprintf(b, "func URL%s(%s) string {\n\treturn %s\n}\n", p.name, strings.Join(args, ", "), p.url)
+
+		for _, pkg := range p.imports {
+			imports[pkg] = true
+		}
+
+		rest = rest || strings.Contains(p.url, "escapeRest(")
+	}
+
+	if rest {
+		imports["net/url"], imports["strings"] = true, true
+		b.WriteString(`
+// escapeRest escapes the segments of a catch-all param, keeping its slashes.
+func escapeRest(rest string) string {
+	segments := strings.Split(rest, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+`)
+	}
+}
+
+// writeClient writes a client for routes, adding the packages it uses to
+// imports.
+func writeClient(b *bytes.Buffer, routes []routetable.Route, paths map[string]*path, imports map[string]bool) error {
+	for _, pkg := range []string{"context", "io", "net/http", "net/url", "strings"} {
+		imports[pkg] = true
+	}
+
+	b.WriteString(`
+// Client sends requests to the routes of a server.
+type Client struct {
+	// BaseURL is the URL of the server, e.g. "https://api.example.com".
+	BaseURL string
+
+	// HTTPClient sends the requests, http.DefaultClient if nil.
+	HTTPClient *http.Client
+
+	// Header is sent with every request.
+	Header http.Header
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, contentType string, body io.Reader) (*http.Response, error) {
+	target := strings.TrimSuffix(c.BaseURL, "/") + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range c.Header {
+		req.Header[key] = values
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return client.Do(req)
+}
+`)
+
+	methods := map[string]string{}
+
+	for _, route := range routes {
+		if route.Method == "" {
+			continue
+		}
+
+		p := paths[route.Path]
+
+		name := exported(route.Name)
+		if name == "" {
+			name = exported(route.Handler)
+		}
+		if name == "" || methods[name] != "" {
+			name = exported(strings.ToLower(route.Method)) + p.name
+		}
+
+		if other, ok := methods[name]; ok {
+			return fmt.Errorf("routes %s and %s both are named %s", other, route, name)
+		}
+		methods[name] = route.String()
+
+		args := []string{"ctx context.Context"}
+		call := make([]string, len(p.params))
+		for i, prm := range p.params {
+			args = append(args, prm.ident+" "+prm.typ)
+			call[i] = prm.ident
+		}
+
+		hasBody := route.Method == http.MethodPost || route.Method == http.MethodPut || route.Method == http.MethodPatch
+		if hasBody {
+			args = append(args, "contentType string", "body io.Reader")
+		} else {
+			args = append(args, "query url.Values")
+		}
+
+		fmt.Fprintf(b, "\n// %s sends %s.\n", name, route)
+		fmt.Fprintf(b, "func (c *Client) %s(%s) (*http.Response, error) {\n", name, strings.Join(args, ", "))
+
+		if hasBody {
+			fmt.Fprintf(b, "\treturn c.do(ctx, %q, URL%s(%s), nil, contentType, body)\n}\n", route.Method, p.name, strings.Join(call, ", "))
+		} else {
+			fmt.Fprintf(b, "\treturn c.do(ctx, %q, URL%s(%s), query, \"\", nil)\n}\n", route.Method, p.name, strings.Join(call, ", "))
+		}
+	}
+
+	return nil
+}
+
+// newPath names pattern after its segments, e.g. "/repos/:owner/pulls"
+// ReposByOwnerPulls, and derives its URL builder.
+func newPath(pattern string) *path {
+	p := &path{pattern: pattern}
+
+	var (
+		name   strings.Builder
+		url    []string
+		static = "/"
+	)
+
+	// names of the generated code and its imports
+	idents := map[string]bool{
+		"ctx": true, "query": true, "contentType": true, "body": true,
+		"c": true, "context": true, "io": true, "http": true, "url": true, "strconv": true, "strings": true,
+	}
+
+	segments := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+
+	for i, segment := range segments {
+		last := i == len(segments)-1
+
+		var prm *param
+
+		switch {
+		case segment == "":
+			if last && i > 0 {
+				name.WriteString("Slash")
+			}
+
+		case segment[0] == ':':
+			paramName, constraint, _ := strings.Cut(segment[1:], ":")
+
+			prm = &param{name: paramName, typ: "string"}
+			if constraint == "[0-9]+" || constraint == `\d+` {
+				prm.typ = "int64"
+			}
+
+		case segment[0] == '*':
+			prm = &param{name: segment[1:], typ: "string"}
+			if prm.name == "" {
+				prm.name = "rest"
+			}
+
+		default:
+			name.WriteString(exported(segment))
+			static += segment
+		}
+
+		if prm != nil {
+			name.WriteString("By" + exported(prm.name))
+
+			prm.ident = unexported(prm.name)
+			for idents[prm.ident] || token.IsKeyword(prm.ident) {
+				prm.ident += "Param"
+			}
+			idents[prm.ident] = true
+
+			url = append(url, strconv.Quote(static))
+			switch {
+			case prm.typ == "int64":
+				url = append(url, "strconv.FormatInt("+prm.ident+", 10)")
+				p.imports = append(p.imports, "strconv")
+			case segment[0] == '*':
+				url = append(url, "escapeRest("+prm.ident+")")
+			default:
+				url = append(url, "url.PathEscape("+prm.ident+")")
+				p.imports = append(p.imports, "net/url")
+			}
+
+			p.params = append(p.params, *prm)
+			static = ""
+		}
+
+		if !last {
+			static += "/"
+		}
+	}
+
+	if static != "" {
+		url = append(url, strconv.Quote(static))
+	}
+
+	p.name = name.String()
+	if p.name == "" {
+		p.name = "Root"
+	}
+
+	p.url = strings.Join(url, " + ")
+
+	return p
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// initialisms are kept in upper case, as Go names do.
+var initialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "ID": true, "JSON": true,
+	"SQL": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// exported turns words such as "received_events" into ReceivedEvents.
+func exported(s string) string {
+	var b strings.Builder
+
+	for _, word := range words(s) {
+		if initialisms[strings.ToUpper(word)] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+
+		runes := []rune(word)
+		b.WriteString(string(unicode.ToUpper(runes[0])) + string(runes[1:]))
+	}
+
+	name := b.String()
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		name = "N" + name
+	}
+
+	return name
+}
+
+func unexported(s string) string {
+	name := exported(s)
+	if name == "" {
+		return "param"
+	}
+
+	first := exported(words(s)[0])
+	if !initialisms[first] {
+		first = first[:1]
+	}
+
+	return strings.ToLower(first) + name[len(first):]
+}
+
+func words(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shyamz-22/router/routetable"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	routes, err := routetable.Load("testdata/routes.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, err := generate(routes, options{pkg: "api", source: "testdata/routes.go", client: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compile(t, code)
+
+	for _, expected := range []string{
+		"// Code generated by routegen from routes.go. DO NOT EDIT.\n\npackage api\n",
+		`PathRoot                                 = "/"`,
+		`PathReposByOwnerPulls                    = "/repos/:owner/pulls"`,
+		`PathReposByOwnerPullsByNumberFilesByPath = "/repos/:owner/pulls/:number:[0-9]+/files/*path"`,
+		`PathItemsByID                            = "/items/:id"`,
+		"func URLRoot() string {\n\treturn \"/\"\n}",
+		"func URLReposByOwnerPulls(owner string) string {\n\treturn \"/repos/\" + url.PathEscape(owner) + \"/pulls\"\n}",
+		"func URLReposByOwnerPullsByNumberFilesByPath(owner string, number int64, path string) string {\n" +
+			"\treturn \"/repos/\" + url.PathEscape(owner) + \"/pulls/\" + strconv.FormatInt(number, 10) + \"/files/\" + escapeRest(path)\n}",
+		"func URLCacheByType(typeParam string) string {",
+		"func (c *Client) ListPulls(ctx context.Context, owner string, query url.Values) (*http.Response, error) {\n" +
+			"\treturn c.do(ctx, \"GET\", URLReposByOwnerPulls(owner), query, \"\", nil)\n}",
+		"func (c *Client) CreatePull(ctx context.Context, owner string, contentType string, body io.Reader) (*http.Response, error) {\n" +
+			"\treturn c.do(ctx, \"POST\", URLReposByOwnerPulls(owner), nil, contentType, body)\n}",
+		"func (c *Client) PutItem(ctx context.Context, id string, contentType string, body io.Reader) (*http.Response, error) {",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Errorf("Expected the code to contain\n%s\n\nCode:\n%s", expected, code)
+		}
+	}
+}
+
+func TestGenerateImports(t *testing.T) {
+	t.Parallel()
+
+	for _, routes := range [][]routetable.Route{
+		{{Method: "GET", Path: "/audio.mp3"}, {Method: "GET", Path: "/strings.txt"}},
+		{{Method: "GET", Path: "/files/*path"}},
+		{{Method: "GET", Path: "/pulls/:number:[0-9]+"}},
+	} {
+		for _, client := range []bool{false, true} {
+			code, err := generate(routes, options{pkg: "api", client: client})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			compile(t, code)
+		}
+	}
+}
+
+func TestGenerateConflictingNames(t *testing.T) {
+	t.Parallel()
+
+	_, err := generate([]routetable.Route{
+		{Method: "GET", Path: "/received-events"},
+		{Method: "GET", Path: "/received_events"},
+	}, options{pkg: "api"})
+
+	if err == nil || err.Error() != `paths "/received-events" and "/received_events" both are named ReceivedEvents` {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	table := filepath.Join(dir, "routes.yaml")
+	out := filepath.Join(dir, "routes_gen.go")
+
+	os.WriteFile(table, []byte("- method: GET\n  path: /pings/:id\n"), 0o644)
+
+	if err := run(table, out, options{pkg: "pings"}); err != nil {
+		t.Fatal(err)
+	}
+
+	code, _ := os.ReadFile(out)
+
+	expected := `// Code generated by routegen from routes.yaml. DO NOT EDIT.
+
+package pings
+
+import (
+	"net/url"
+)
+
+// Route patterns.
+const (
+	PathPingsByID = "/pings/:id"
+)
+
+// URLPingsByID returns the path of /pings/:id.
+func URLPingsByID(id string) string {
+	return "/pings/" + url.PathEscape(id)
+}
+`
+	if string(code) != expected {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expected, code)
+	}
+}
+
+// compile type checks generated code, failing t on unused or missing imports.
+func compile(t *testing.T, code []byte) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "routes_gen.go", code, 0)
+	if err != nil {
+		t.Fatalf("%v\n\nCode:\n%s", err, code)
+	}
+
+	conf := types.Config{Importer: importer.Default()}
+	if _, err := conf.Check("api", fset, []*ast.File{file}, nil); err != nil {
+		t.Errorf("%v\n\nCode:\n%s", err, code)
+	}
+}
@@ -0,0 +1,71 @@
+// Command routegen generates Go code for the routes of a route table, so
+// callers and tests never concatenate paths by hand:
+//
+//	routegen -pkg api -o routes_gen.go routes.go
+//
+// The route table is Go source registering routes or a JSON or YAML file,
+// see package routetable. For every path routegen generates a constant with
+// the pattern and a URL builder taking one argument per path param, e.g.
+//
+//	const PathReposByOwnerPulls = "/repos/:owner/pulls"
+//
+//	func URLReposByOwnerPulls(owner string) string
+//
+// and for every route a method of Client sending the request, named after
+// the name or handler of the route, or else its method and path, e.g.
+// Client.GetReposByOwnerPulls. Params constrained to digits are int64
+// arguments.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/shyamz-22/router/routetable"
+)
+
+func main() {
+	var (
+		pkg    = flag.String("pkg", "routes", "package `name` of the generated code")
+		out    = flag.String("o", "", "write the code to `file` instead of stdout")
+		client = flag.Bool("client", true, "generate the HTTP client")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: routegen [flags] routes.{go,json,yaml}\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *out, options{pkg: *pkg, client: *client}); err != nil {
+		fmt.Fprintln(os.Stderr, "routegen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out string, opts options) error {
+	routes, err := routetable.Load(in)
+	if err != nil {
+		return err
+	}
+
+	opts.source = in
+
+	code, err := generate(routes, opts)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+
+	return os.WriteFile(out, code, 0o644)
+}
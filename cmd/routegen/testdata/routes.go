@@ -0,0 +1,19 @@
+package testdata
+
+import (
+	"net/http"
+
+	"github.com/shyamz-22/router"
+)
+
+func register(rtr *router.Router) {
+	rtr.Add("/", http.MethodGet, index)
+
+	repos := rtr.Group("/repos/:owner")
+	repos.AddGet("/pulls", listPulls)
+	repos.AddPost("/pulls", createPull)
+	repos.AddGet("/pulls/:number:[0-9]+/files/*path", getFile)
+
+	rtr.HandleFunc(http.MethodDelete, "/cache/:type", flush)
+	rtr.Add("PUT /items/{id}", "", putItem)
+}
@@ -0,0 +1,100 @@
+// Command routematch answers how a route table dispatches requests, to debug
+// why a request is not found without writing Go:
+//
+//	routematch -routes fixture/fixtures.go -table Routes 'GET /repos/a/b/pulls/1'
+//
+// The route table is Go source registering routes, a fixture table such as
+// fixture.Routes, or a JSON or YAML file, see package routetable. Queries
+// are "METHOD /path" arguments, or lines of stdin if there are none. For
+// each query routematch prints the matched route and its params and the
+// allowed methods, or why no route matches. With -dump it prints the tree
+// the routes are matched by.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/shyamz-22/router/routetable"
+)
+
+func main() {
+	var (
+		file  = flag.String("routes", "", "route table `file`, Go, JSON or YAML")
+		table = flag.String("table", "", "read the fixture table of Go `variable` only")
+		dump  = flag.Bool("dump", false, "print the tree of the routes")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: routematch -routes file [flags] ['METHOD /path' ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(os.Stdout, os.Stdin, *file, *table, *dump, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "routematch:", err)
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer, stdin io.Reader, file, table string, dump bool, queries []string) error {
+	routes, err := routetable.LoadTable(file, table)
+	if err != nil {
+		return err
+	}
+
+	m, err := newMatcher(file, routes)
+	if err != nil {
+		return err
+	}
+
+	if dump {
+		if err := m.rtr.DumpTree(w); err != nil {
+			return err
+		}
+
+		if len(queries) == 0 {
+			return nil
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(queries) > 0 {
+		for i, query := range queries {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			m.explain(w, query)
+		}
+
+		return nil
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	first := true
+
+	for scanner.Scan() {
+		query := strings.TrimSpace(scanner.Text())
+		if query == "" || strings.HasPrefix(query, "#") {
+			continue
+		}
+
+		if !first {
+			fmt.Fprintln(w)
+		}
+		first = false
+
+		m.explain(w, query)
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/shyamz-22/router"
+	"github.com/shyamz-22/router/routetable"
+)
+
+// matcher dispatches queries with a router holding the routes of a table.
+type matcher struct {
+	rtr    *router.Router
+	routes []routetable.Route
+}
+
+func newMatcher(file string, routes []routetable.Route) (*matcher, error) {
+	m := &matcher{rtr: router.New(), routes: routes}
+
+	noop := func(w http.ResponseWriter, r *http.Request, params router.PathParams) {}
+
+	for _, route := range routes {
+		if err := m.rtr.Check(route.Path, route.Method); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", file, route.Line, err)
+		}
+
+		m.rtr.Add(route.Path, route.Method, noop)
+	}
+
+	return m, nil
+}
+
+// explain prints how a query such as "GET /repos/a" is answered.
+func (m *matcher) explain(w io.Writer, query string) {
+	method, target, ok := strings.Cut(query, " ")
+	if !ok {
+		method, target = http.MethodGet, query
+	}
+
+	method = strings.ToUpper(method)
+	target = strings.TrimSpace(target)
+
+	path := target
+	if u, err := url.Parse(target); err == nil && u.Path != "" {
+		path = u.Path
+	}
+
+	fmt.Fprintf(w, "%s %s\n", method, target)
+
+	field := func(label, value string) {
+		fmt.Fprintf(w, "  %-8s %s\n", label+":", value)
+	}
+
+	allowed := m.rtr.Allowed(path)
+
+	if route, params := m.rtr.Lookup(method, path); route != nil {
+		field("status", status(http.StatusOK))
+		field("route", describe(route))
+
+		if route.Method != method && route.Method != "" {
+			field("note", fmt.Sprintf("%s is answered by the %s route", method, route.Method))
+		}
+
+		if len(params) > 0 {
+			values := make([]string, len(params))
+			for i, p := range params {
+				values[i] = p.Key + "=" + p.Value
+			}
+			field("params", strings.Join(values, ", "))
+		}
+
+		field("allow", strings.Join(allowed, ", "))
+		return
+	}
+
+	switch {
+	case method == http.MethodOptions && len(allowed) > 0:
+		field("status", status(http.StatusNoContent))
+		field("reason", "OPTIONS is answered by the router")
+		field("allow", strings.Join(allowed, ", "))
+
+	case len(allowed) > 0:
+		field("status", status(http.StatusMethodNotAllowed))
+		field("reason", fmt.Sprintf("the path has no %s route", method))
+		field("allow", strings.Join(allowed, ", "))
+
+	default:
+		field("status", status(http.StatusNotFound))
+		field("reason", m.notFound(method, path))
+	}
+}
+
+// notFound explains why no route matches path, by the route that matches
+// most of its segments.
+func (m *matcher) notFound(method, path string) string {
+	if len(m.routes) == 0 {
+		return "the table has no routes"
+	}
+
+	var (
+		closest routetable.Route
+		best    = -1
+		why     string
+	)
+
+	for _, route := range m.routes {
+		matched, reason := matchSegments(route.Path, path)
+
+		// routes of the requested method win a tie
+		if matched > best || matched == best && route.Method == method && closest.Method != method {
+			closest, best, why = route, matched, reason
+		}
+	}
+
+	reason := fmt.Sprintf("no route matches, closest is %s: %s", closest, why)
+
+	for _, alternative := range []string{strings.TrimSuffix(path, "/"), path + "/"} {
+		if alternative != path && alternative != "" {
+			if route, _ := m.rtr.Lookup(method, alternative); route != nil {
+				reason += fmt.Sprintf("; %s would match %s", alternative, describe(route))
+			}
+		}
+	}
+
+	return reason
+}
+
+// matchSegments counts the leading segments of path pattern matches and
+// tells why the next one does not.
+func matchSegments(pattern, path string) (int, string) {
+	patterns := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	for i, p := range patterns {
+		if strings.HasPrefix(p, "*") {
+			return len(segments), "it matches"
+		}
+
+		if i >= len(segments) {
+			return i, fmt.Sprintf("the path ends before segment %q", p)
+		}
+
+		segment := segments[i]
+
+		switch {
+		case strings.HasPrefix(p, ":"):
+			name, constraint, ok := strings.Cut(p[1:], ":")
+			if !ok {
+				continue
+			}
+
+			re, err := regexp.Compile("^(?:" + constraint + ")$")
+			if err == nil && !re.MatchString(segment) {
+				return i, fmt.Sprintf("segment %q does not match %s of :%s", segment, constraint, name)
+			}
+
+		case p != segment:
+			return i, fmt.Sprintf("segment %q is not %q", segment, p)
+		}
+	}
+
+	if len(segments) > len(patterns) {
+		return len(patterns), fmt.Sprintf("the path continues with %q", "/"+strings.Join(segments[len(patterns):], "/"))
+	}
+
+	return len(patterns), "it matches another method"
+}
+
+func describe(route *router.Route) string {
+	if route.Method == "" {
+		return route.Path + " (all methods)"
+	}
+
+	return route.Method + " " + route.Path
+}
+
+func status(code int) string {
+	return fmt.Sprintf("%d %s", code, http.StatusText(code))
+}
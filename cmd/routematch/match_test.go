@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		queries []string
+		stdin   string
+		output  string
+	}{
+		{
+			name:    "matched route",
+			queries: []string{"GET /repos/golang/pulls/42?state=open"},
+			output: `GET /repos/golang/pulls/42?state=open
+  status:  200 OK
+  route:   GET /repos/:owner/pulls/:number:[0-9]+
+  params:  owner=golang, number=42
+  allow:   GET, HEAD, OPTIONS
+`,
+		},
+		{
+			name:    "method not allowed",
+			queries: []string{"DELETE /repos/golang"},
+			output: `DELETE /repos/golang
+  status:  405 Method Not Allowed
+  reason:  the path has no DELETE route
+  allow:   GET, HEAD, OPTIONS, POST
+`,
+		},
+		{
+			name:    "not found",
+			queries: []string{"GET /repos/golang/pulls/latest", "get /feeds/"},
+			output: `GET /repos/golang/pulls/latest
+  status:  404 Not Found
+  reason:  no route matches, closest is GET /repos/:owner/pulls/:number:[0-9]+: segment "latest" does not match [0-9]+ of :number
+
+GET /feeds/
+  status:  404 Not Found
+  reason:  no route matches, closest is GET /feeds: the path continues with "/"; /feeds would match GET /feeds
+`,
+		},
+		{
+			name:  "queries from stdin",
+			stdin: "# automatic replies\nOPTIONS /feeds\n\nHEAD /feeds\n",
+			output: `OPTIONS /feeds
+  status:  204 No Content
+  reason:  OPTIONS is answered by the router
+  allow:   GET, HEAD, OPTIONS
+
+HEAD /feeds
+  status:  200 OK
+  route:   GET /feeds
+  note:    HEAD is answered by the GET route
+  allow:   GET, HEAD, OPTIONS
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var out strings.Builder
+
+			if err := run(&out, strings.NewReader(tc.stdin), "testdata/routes.yaml", "", false, tc.queries); err != nil {
+				t.Fatal(err)
+			}
+
+			if out.String() != tc.output {
+				t.Errorf("\nExpected:\n%s\nActual:\n%s", tc.output, out.String())
+			}
+		})
+	}
+}
+
+func TestRunFixtureTable(t *testing.T) {
+	t.Parallel()
+	var out strings.Builder
+
+	err := run(&out, nil, "../../fixture/fixtures.go", "MuxRoutes", false, []string{"GET /repos/golang/go/pulls/1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out.String(), "route:   GET /repos/:owner/:repo/pulls/:number\n") {
+		t.Errorf("Unexpected output\n%s", out.String())
+	}
+}
+
+func TestRunInvalidTable(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir() + "/routes.json"
+	writeFile(t, dir, `[
+  {"method": "GET", "path": "/pings/:id"},
+  {"method": "GET", "path": "/pings/:id:[0-9"}
+]`)
+
+	err := run(&strings.Builder{}, nil, dir, "", false, nil)
+	if err == nil || !strings.Contains(err.Error(), "routes.json:3: Invalid Path Param: :id:[0-9.") {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
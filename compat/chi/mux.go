@@ -0,0 +1,263 @@
+// Package chi offers the registration API of github.com/go-chi/chi on top
+// of router.Router, so services can switch routers by changing an import.
+//
+// Patterns use the chi syntax, "{name}" and "{name:regexp}" params and a
+// trailing "*" wildcard, read with URLParam(r, "*"). Params must span whole
+// segments. Unlike chi, a static segment wins over a param regardless of
+// the registration order.
+package chi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/shyamz-22/router"
+	"github.com/shyamz-22/router/compat/internal/brace"
+)
+
+// Router consisting of the core routing methods used by chi's Mux.
+type Router interface {
+	http.Handler
+
+	// Use appends one of more middlewares onto the Router stack.
+	Use(middlewares ...func(http.Handler) http.Handler)
+
+	// With adds inline middlewares for an endpoint handler.
+	With(middlewares ...func(http.Handler) http.Handler) Router
+
+	// Group adds a new inline-Router along the current routing
+	// path, with a fresh middleware stack for the inline-Router.
+	Group(fn func(r Router)) Router
+
+	// Route mounts a sub-Router along a `pattern` string.
+	Route(pattern string, fn func(r Router)) Router
+
+	// Mount attaches another http.Handler along ./pattern/*
+	Mount(pattern string, h http.Handler)
+
+	// Handle and HandleFunc adds routes for `pattern` that matches
+	// all HTTP methods.
+	Handle(pattern string, h http.Handler)
+	HandleFunc(pattern string, h http.HandlerFunc)
+
+	// Method and MethodFunc adds routes for `pattern` that matches
+	// the `method` HTTP method.
+	Method(method, pattern string, h http.Handler)
+	MethodFunc(method, pattern string, h http.HandlerFunc)
+
+	// HTTP-method routing along `pattern`
+	Connect(pattern string, h http.HandlerFunc)
+	Delete(pattern string, h http.HandlerFunc)
+	Get(pattern string, h http.HandlerFunc)
+	Head(pattern string, h http.HandlerFunc)
+	Options(pattern string, h http.HandlerFunc)
+	Patch(pattern string, h http.HandlerFunc)
+	Post(pattern string, h http.HandlerFunc)
+	Put(pattern string, h http.HandlerFunc)
+	Trace(pattern string, h http.HandlerFunc)
+
+	// NotFound defines a handler to respond whenever a route could
+	// not be found.
+	NotFound(h http.HandlerFunc)
+
+	// MethodNotAllowed defines a handler to respond whenever a method is
+	// not allowed.
+	MethodNotAllowed(h http.HandlerFunc)
+}
+
+// Mux is a Router backed by router.Router. Inline and sub-routers are
+// router.Groups of the same router.Router.
+type Mux struct {
+	rtr   *router.Router
+	group *router.Group
+}
+
+var _ Router = &Mux{}
+
+// NewRouter returns a new Mux object that implements the Router interface.
+func NewRouter() *Mux {
+	return NewMux()
+}
+
+// NewMux returns a newly initialized Mux object that implements the Router
+// interface.
+func NewMux() *Mux {
+	return &Mux{rtr: router.New()}
+}
+
+// ServeHTTP is the single method of the http.Handler interface.
+func (mx *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mx.rtr.ServeHTTP(w, r)
+}
+
+// Use appends a middleware handler to the Mux middleware stack. Unlike chi,
+// Use may also be called after routes were added, they are wrapped as well.
+func (mx *Mux) Use(middlewares ...func(http.Handler) http.Handler) {
+	mx.registrar().Use(adapt(middlewares)...)
+}
+
+// With adds inline middlewares for an endpoint handler.
+func (mx *Mux) With(middlewares ...func(http.Handler) http.Handler) Router {
+	return &Mux{rtr: mx.rtr, group: mx.registrar().Group("", adapt(middlewares)...)}
+}
+
+// Group creates a new inline-Mux with a fresh middleware stack. It's useful
+// for a group of handlers along the same routing path that use an additional
+// set of middlewares.
+func (mx *Mux) Group(fn func(r Router)) Router {
+	im := &Mux{rtr: mx.rtr, group: mx.registrar().Group("")}
+	if fn != nil {
+		fn(im)
+	}
+
+	return im
+}
+
+// Route creates a new Mux with a fresh middleware stack and mounts it along
+// the `pattern` as a subrouter.
+func (mx *Mux) Route(pattern string, fn func(r Router)) Router {
+	subRouter := &Mux{rtr: mx.rtr, group: mx.registrar().Group(brace.Translate(pattern))}
+	if fn != nil {
+		fn(subRouter)
+	}
+
+	return subRouter
+}
+
+// Mount attaches another http.Handler or chi Router as a subrouter along a
+// routing path. The handler sees the path below pattern.
+func (mx *Mux) Mount(pattern string, handler http.Handler) {
+	if sub, ok := handler.(*Mux); ok {
+		handler = sub.rtr
+	}
+
+	mx.registrar().Mount(brace.Translate(pattern), handler)
+}
+
+// Handle adds the route `pattern` that matches any http method to
+// execute the `handler` http.Handler.
+func (mx *Mux) Handle(pattern string, handler http.Handler) {
+	mx.Method("", pattern, handler)
+}
+
+// HandleFunc adds the route `pattern` that matches any http method to
+// execute the `handlerFn` http.HandlerFunc.
+func (mx *Mux) HandleFunc(pattern string, handlerFn http.HandlerFunc) {
+	mx.Method("", pattern, handlerFn)
+}
+
+// Method adds the route `pattern` that matches `method` http method to
+// execute the `handler` http.Handler.
+func (mx *Mux) Method(method, pattern string, handler http.Handler) {
+	mx.registrar().Handle(method, brace.Translate(pattern), handler)
+}
+
+// MethodFunc adds the route `pattern` that matches `method` http method to
+// execute the `handlerFn` http.HandlerFunc.
+func (mx *Mux) MethodFunc(method, pattern string, handlerFn http.HandlerFunc) {
+	mx.Method(method, pattern, handlerFn)
+}
+
+// Connect adds the route `pattern` that matches a CONNECT http method to
+// execute the `handlerFn` http.HandlerFunc.
+func (mx *Mux) Connect(pattern string, handlerFn http.HandlerFunc) {
+	mx.Method(http.MethodConnect, pattern, handlerFn)
+}
+
+// Delete adds the route `pattern` that matches a DELETE http method to
+// execute the `handlerFn` http.HandlerFunc.
+func (mx *Mux) Delete(pattern string, handlerFn http.HandlerFunc) {
+	mx.Method(http.MethodDelete, pattern, handlerFn)
+}
+
+// Get adds the route `pattern` that matches a GET http method to
+// execute the `handlerFn` http.HandlerFunc.
+func (mx *Mux) Get(pattern string, handlerFn http.HandlerFunc) {
+	mx.Method(http.MethodGet, pattern, handlerFn)
+}
+
+// Head adds the route `pattern` that matches a HEAD http method to
+// execute the `handlerFn` http.HandlerFunc.
+func (mx *Mux) Head(pattern string, handlerFn http.HandlerFunc) {
+	mx.Method(http.MethodHead, pattern, handlerFn)
+}
+
+// Options adds the route `pattern` that matches a OPTIONS http method to
+// execute the `handlerFn` http.HandlerFunc.
+func (mx *Mux) Options(pattern string, handlerFn http.HandlerFunc) {
+	mx.Method(http.MethodOptions, pattern, handlerFn)
+}
+
+// Patch adds the route `pattern` that matches a PATCH http method to
+// execute the `handlerFn` http.HandlerFunc.
+func (mx *Mux) Patch(pattern string, handlerFn http.HandlerFunc) {
+	mx.Method(http.MethodPatch, pattern, handlerFn)
+}
+
+// Post adds the route `pattern` that matches a POST http method to
+// execute the `handlerFn` http.HandlerFunc.
+func (mx *Mux) Post(pattern string, handlerFn http.HandlerFunc) {
+	mx.Method(http.MethodPost, pattern, handlerFn)
+}
+
+// Put adds the route `pattern` that matches a PUT http method to
+// execute the `handlerFn` http.HandlerFunc.
+func (mx *Mux) Put(pattern string, handlerFn http.HandlerFunc) {
+	mx.Method(http.MethodPut, pattern, handlerFn)
+}
+
+// Trace adds the route `pattern` that matches a TRACE http method to
+// execute the `handlerFn` http.HandlerFunc.
+func (mx *Mux) Trace(pattern string, handlerFn http.HandlerFunc) {
+	mx.Method(http.MethodTrace, pattern, handlerFn)
+}
+
+// NotFound sets a custom http.HandlerFunc for routing paths that could
+// not be found. On a sub-router it applies to paths below its pattern.
+func (mx *Mux) NotFound(handlerFn http.HandlerFunc) {
+	if mx.group != nil {
+		mx.group.NotFound(handlerFn)
+		return
+	}
+
+	mx.rtr.NotFound = handlerFn
+}
+
+// MethodNotAllowed sets a custom http.HandlerFunc for routing paths where the
+// method is unresolved. On a sub-router it applies to paths below its
+// pattern.
+func (mx *Mux) MethodNotAllowed(handlerFn http.HandlerFunc) {
+	if mx.group != nil {
+		mx.group.MethodNotAllowed(handlerFn)
+		return
+	}
+
+	mx.rtr.MethodNotAllowed = handlerFn
+}
+
+func (mx *Mux) registrar() router.Registrar {
+	if mx.group != nil {
+		return mx.group
+	}
+
+	return mx.rtr
+}
+
+func adapt(middlewares []func(http.Handler) http.Handler) []router.Middleware {
+	adapted := make([]router.Middleware, len(middlewares))
+	for i, mw := range middlewares {
+		adapted[i] = router.StdMiddleware(mw)
+	}
+
+	return adapted
+}
+
+// URLParam returns the url parameter from a http.Request object.
+func URLParam(r *http.Request, key string) string {
+	return URLParamFromCtx(r.Context(), key)
+}
+
+// URLParamFromCtx returns the url parameter from a http.Request Context.
+func URLParamFromCtx(ctx context.Context, key string) string {
+	return router.ParamsFromContext(ctx).ByName(key)
+}
@@ -0,0 +1,191 @@
+package chi
+
+import (
+	"github.com/shyamz-22/router/assert"
+	"github.com/shyamz-22/router/fixture"
+
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMux(t *testing.T) {
+	t.Parallel()
+	rtr := NewRouter()
+	rtr.Get("/articles/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("article " + URLParam(r, "id")))
+	})
+
+	rtr.Get("/articles/{slug}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("slug " + URLParam(r, "slug")))
+	})
+
+	rtr.Get("/articles/{id}/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("trailing " + URLParam(r, "id")))
+	})
+
+	rtr.HandleFunc("/files/*", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method + " " + URLParam(r, "*")))
+	})
+
+	rtr.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	rtr.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+
+	for _, tc := range []struct {
+		method, path string
+		status       int
+		body         string
+	}{
+		{http.MethodGet, "/articles/42", http.StatusOK, "article 42"},
+		{http.MethodGet, "/articles/go-1-22", http.StatusOK, "slug go-1-22"},
+		{http.MethodGet, "/articles/42/", http.StatusOK, "trailing 42"},
+		{http.MethodPut, "/files/a/b.txt", http.StatusOK, "PUT a/b.txt"},
+		{http.MethodGet, "/articles/42/comments", http.StatusTeapot, ""},
+		{http.MethodPost, "/articles/42", http.StatusConflict, ""},
+	} {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(tc.method, tc.path, nil)
+
+			rtr.ServeHTTP(w, r)
+
+			assert.ResponseWithBody(t, w, tc.status, tc.body)
+		})
+	}
+}
+
+func TestMuxWithGithubRoutes(t *testing.T) {
+	t.Parallel()
+	rtr := NewRouter()
+	for _, route := range fixture.MuxRoutes {
+		rtr.MethodFunc(route.Method, route.Path, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+
+	for _, route := range fixture.RoutesWithPathValues {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(route.Method, route.Path, nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusOK)
+	}
+}
+
+func TestMuxWithMiddlewares(t *testing.T) {
+	t.Parallel()
+	header := func(key, value string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add(key, value)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	rtr := NewRouter()
+	rtr.Use(header("X-Trace", "global"))
+
+	rtr.With(header("X-Trace", "inline")).Get("/articles/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(URLParam(r, "id")))
+	})
+
+	rtr.Get("/articles", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("articles"))
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/articles/1", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	assert.ResponseWithBody(t, w, http.StatusOK, "1")
+	if trace := w.Result().Header["X-Trace"]; len(trace) != 2 || trace[0] != "global" || trace[1] != "inline" {
+		t.Fatalf("\nExpected: [global inline]\nActual:%v\n", trace)
+	}
+
+	w = httptest.NewRecorder()
+	r, _ = http.NewRequest(http.MethodGet, "/articles", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	assert.ResponseWithBody(t, w, http.StatusOK, "articles")
+	assert.ResponseWithHeader(t, w, "X-Trace", "global")
+}
+
+func TestMuxWithSubRouters(t *testing.T) {
+	t.Parallel()
+	rtr := NewRouter()
+	rtr.Route("/repos/{owner}", func(r Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("repos of " + URLParam(r, "owner")))
+		})
+
+		r.Group(func(r Router) {
+			r.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("X-Auth", "required")
+					next.ServeHTTP(w, r)
+				})
+			})
+
+			r.Delete("/{repo}", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("deleted " + URLParam(r, "owner") + "/" + URLParam(r, "repo")))
+			})
+		})
+
+		r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+	})
+
+	hooks := NewRouter()
+	hooks.Post("/{hook}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hook " + URLParam(r, "hook") + " of " + URLParam(r, "owner")))
+	})
+	rtr.Mount("/hooks/{owner}", hooks)
+
+	t.Run("serves routes of the sub-router", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/repos/gopher/", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "repos of gopher")
+		assert.ResponseWithHeader(t, w, "X-Auth", "")
+	})
+
+	t.Run("serves routes of the inline router", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodDelete, "/repos/gopher/router", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "deleted gopher/router")
+		assert.ResponseWithHeader(t, w, "X-Auth", "required")
+	})
+
+	t.Run("uses the not found handler of the sub-router", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/repos/gopher/router/issues", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusTeapot)
+	})
+
+	t.Run("serves routes of a mounted router", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodPost, "/hooks/gopher/push", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "hook push of gopher")
+	})
+}
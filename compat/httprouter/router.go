@@ -0,0 +1,161 @@
+// Package httprouter offers the registration API of
+// github.com/julienschmidt/httprouter on top of router.Router, so services
+// can switch routers by changing an import.
+//
+// Paths use the httprouter syntax, ":name" params and a trailing
+// "*name" catch-all, whose value starts with "/". Unlike httprouter, a
+// static segment and a param may share a position, the static one wins.
+package httprouter
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/shyamz-22/router"
+)
+
+// Handle is a function that can be registered to a route to handle HTTP
+// requests. It gets the values of the path params as third argument.
+type Handle func(http.ResponseWriter, *http.Request, Params)
+
+// Param is a single URL parameter, consisting of a key and a value.
+type Param = router.Param
+
+// Params is a Param-slice, as returned by the router.
+type Params []Param
+
+// ByName returns the value of the first Param whose key matches name, or
+// an empty string if there is none.
+func (ps Params) ByName(name string) string {
+	return router.PathParams(ps).ByName(name)
+}
+
+// ParamsFromContext pulls the URL parameters from a request context of a
+// route registered with Handler or HandlerFunc.
+func ParamsFromContext(ctx context.Context) Params {
+	return Params(withSlash(ctx, router.ParamsFromContext(ctx)))
+}
+
+// Router dispatches requests to handles registered with httprouter paths.
+// The NotFound, MethodNotAllowed, PanicHandler, HandleOPTIONS and
+// GlobalOPTIONS fields behave like their httprouter counterparts.
+type Router struct {
+	*router.Router
+}
+
+// New returns a new initialized Router.
+func New() *Router {
+	return &Router{Router: router.New()}
+}
+
+// GET is a shortcut for router.Handle(http.MethodGet, path, handle).
+func (r *Router) GET(path string, handle Handle) {
+	r.Handle(http.MethodGet, path, handle)
+}
+
+// HEAD is a shortcut for router.Handle(http.MethodHead, path, handle).
+func (r *Router) HEAD(path string, handle Handle) {
+	r.Handle(http.MethodHead, path, handle)
+}
+
+// OPTIONS is a shortcut for router.Handle(http.MethodOptions, path, handle).
+func (r *Router) OPTIONS(path string, handle Handle) {
+	r.Handle(http.MethodOptions, path, handle)
+}
+
+// POST is a shortcut for router.Handle(http.MethodPost, path, handle).
+func (r *Router) POST(path string, handle Handle) {
+	r.Handle(http.MethodPost, path, handle)
+}
+
+// PUT is a shortcut for router.Handle(http.MethodPut, path, handle).
+func (r *Router) PUT(path string, handle Handle) {
+	r.Handle(http.MethodPut, path, handle)
+}
+
+// PATCH is a shortcut for router.Handle(http.MethodPatch, path, handle).
+func (r *Router) PATCH(path string, handle Handle) {
+	r.Handle(http.MethodPatch, path, handle)
+}
+
+// DELETE is a shortcut for router.Handle(http.MethodDelete, path, handle).
+func (r *Router) DELETE(path string, handle Handle) {
+	r.Handle(http.MethodDelete, path, handle)
+}
+
+// Handle registers a new request handle with the given path and method.
+func (r *Router) Handle(method, path string, handle Handle) {
+	catchAll := hasCatchAll(path)
+
+	r.Add(path, method, func(w http.ResponseWriter, req *http.Request, params router.PathParams) {
+		if catchAll && len(params) > 0 {
+			// params may be shared with the request context
+			params = append(router.PathParams(nil), params...)
+			params[len(params)-1].Value = "/" + params[len(params)-1].Value
+		}
+
+		handle(w, req, Params(params))
+	})
+}
+
+// Handler is an adapter which allows the usage of an http.Handler as a
+// request handle. The Params are available through ParamsFromContext.
+func (r *Router) Handler(method, path string, handler http.Handler) {
+	if hasCatchAll(path) {
+		handler = catchAllHandler{handler}
+	}
+
+	r.Router.Handle(method, path, handler)
+}
+
+// HandlerFunc is an adapter which allows the usage of an http.HandlerFunc as
+// a request handle.
+func (r *Router) HandlerFunc(method, path string, handler http.HandlerFunc) {
+	r.Handler(method, path, handler)
+}
+
+// ServeFiles serves files from the given file system root. The path must
+// end with "/*filepath", files are then served from the local path
+// /defined/root/dir/*filepath.
+func (r *Router) ServeFiles(path string, root http.FileSystem) {
+	if len(path) < 10 || path[len(path)-10:] != "/*filepath" {
+		panic("path must end with /*filepath in path '" + path + "'")
+	}
+
+	fileServer := http.FileServer(root)
+
+	r.GET(path, func(w http.ResponseWriter, req *http.Request, ps Params) {
+		req.URL.Path = ps.ByName("filepath")
+		fileServer.ServeHTTP(w, req)
+	})
+}
+
+type catchAllKey struct{}
+
+// catchAllHandler marks requests whose last param is a catch-all, so
+// ParamsFromContext can prefix its value with a slash.
+type catchAllHandler struct {
+	http.Handler
+}
+
+func (h catchAllHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	h.Handler.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), catchAllKey{}, true)))
+}
+
+func withSlash(ctx context.Context, params router.PathParams) router.PathParams {
+	if len(params) == 0 || ctx.Value(catchAllKey{}) == nil {
+		return params
+	}
+
+	ps := make(router.PathParams, len(params))
+	copy(ps, params)
+	ps[len(ps)-1].Value = "/" + ps[len(ps)-1].Value
+
+	return ps
+}
+
+func hasCatchAll(path string) bool {
+	i := strings.LastIndexByte(path, '/')
+	return i >= 0 && len(path) > i+2 && path[i+1] == '*'
+}
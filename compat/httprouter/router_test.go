@@ -0,0 +1,101 @@
+package httprouter
+
+import (
+	"github.com/shyamz-22/router/assert"
+	"github.com/shyamz-22/router/fixture"
+
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.GET("/users/:user", func(w http.ResponseWriter, r *http.Request, ps Params) {
+		w.Write([]byte("user " + ps.ByName("user")))
+	})
+
+	rtr.GET("/src/*filepath", func(w http.ResponseWriter, r *http.Request, ps Params) {
+		w.Write([]byte("src " + ps.ByName("filepath")))
+	})
+
+	rtr.HandlerFunc(http.MethodPost, "/users/:user/blobs/*path", func(w http.ResponseWriter, r *http.Request) {
+		ps := ParamsFromContext(r.Context())
+		w.Write([]byte(ps.ByName("user") + " " + ps.ByName("path")))
+	})
+
+	rtr.ServeFiles("/static/*filepath", http.FS(fstest.MapFS{
+		"app.js": {Data: []byte("console.log()")},
+	}))
+
+	rtr.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	for _, tc := range []struct {
+		method, path string
+		status       int
+		body         string
+	}{
+		{http.MethodGet, "/users/gopher", http.StatusOK, "user gopher"},
+		{http.MethodGet, "/src/", http.StatusOK, "src /"},
+		{http.MethodGet, "/src/cmd/main.go", http.StatusOK, "src /cmd/main.go"},
+		{http.MethodPost, "/users/gopher/blobs/a/b", http.StatusOK, "gopher /a/b"},
+		{http.MethodGet, "/static/app.js", http.StatusOK, "console.log()"},
+		{http.MethodGet, "/unknown", http.StatusTeapot, ""},
+	} {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(tc.method, tc.path, nil)
+
+			rtr.ServeHTTP(w, r)
+
+			assert.ResponseWithBody(t, w, tc.status, tc.body)
+		})
+	}
+}
+
+func TestRouterWithRootCatchAll(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.GET("/*filepath", func(w http.ResponseWriter, r *http.Request, ps Params) {
+		w.Write([]byte("file " + ps.ByName("filepath")))
+	})
+
+	for _, tc := range []struct {
+		path, body string
+	}{
+		{"/", "file /"},
+		{"/a/b", "file /a/b"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodGet, tc.path, nil)
+
+			rtr.ServeHTTP(w, r)
+
+			assert.ResponseWithBody(t, w, http.StatusOK, tc.body)
+		})
+	}
+}
+
+func TestRouterWithGithubRoutes(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	for _, route := range fixture.Routes {
+		rtr.Handle(route.Method, route.Path, func(w http.ResponseWriter, r *http.Request, ps Params) {
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+
+	for _, route := range fixture.RoutesWithPathValues {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(route.Method, route.Path, nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusOK)
+	}
+}
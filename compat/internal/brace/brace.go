@@ -0,0 +1,29 @@
+// Package brace translates the brace syntax of chi and gorilla/mux into
+// the path syntax of the router.
+package brace
+
+import (
+	"strings"
+)
+
+// Translate translates "{name}" and "{name:regexp}" segments into ":name"
+// and ":name:regexp", and a trailing "*" into a catch-all named "*". Other
+// segments, trailing slashes included, are kept as they are.
+func Translate(pattern string) string {
+	if !strings.ContainsAny(pattern, "{*") {
+		return pattern
+	}
+
+	segments := strings.Split(pattern, "/")
+
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			segments[i] = ":" + segment[1:len(segment)-1]
+		case segment == "*" && i == len(segments)-1:
+			segments[i] = "**"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
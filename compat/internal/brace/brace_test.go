@@ -0,0 +1,21 @@
+package brace
+
+import (
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+	for pattern, expected := range map[string]string{
+		"/articles/":             "/articles/",
+		"/articles/{id}":         "/articles/:id",
+		"/articles/{id:[0-9]+}/": "/articles/:id:[0-9]+/",
+		"/years/{year:[0-9]{4}}": "/years/:year:[0-9]{4}",
+		"/static/*":              "/static/**",
+		"/{owner}/{repo}/blob/*": "/:owner/:repo/blob/**",
+	} {
+		if actual := Translate(pattern); actual != expected {
+			t.Errorf("\nPattern: %s\nExpected: %s\nActual:%s\n", pattern, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+// Package mux offers the registration API of github.com/gorilla/mux on top
+// of router.Router, so services can switch routers by changing an import.
+//
+// Path templates use the gorilla syntax, "{name}" and "{name:regexp}"
+// variables, which must span whole segments. Routes are matched by the
+// router's tree, so a static segment wins over a variable regardless of the
+// registration order. Of two routes with the same template and method, the
+// first one registered wins, as in gorilla/mux.
+package mux
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/shyamz-22/router"
+	"github.com/shyamz-22/router/compat/internal/brace"
+)
+
+// Router registers routes to be matched and dispatches a handler.
+//
+// The routes are compiled into a router.Router on the first request after
+// a route was changed.
+type Router struct {
+	// Configurable Handler to be used when no route matches.
+	NotFoundHandler http.Handler
+
+	// Configurable Handler to be used when the request method does not match
+	// the route.
+	MethodNotAllowedHandler http.Handler
+
+	parent      *Router
+	prefix      string
+	methods     []string
+	middlewares []MiddlewareFunc
+	routes      []*Route
+
+	mu       sync.Mutex
+	compiled *router.Router
+}
+
+// NewRouter returns a new router instance.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// ServeHTTP dispatches the handler registered in the matched route.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.top().compile().ServeHTTP(w, req)
+}
+
+// NewRoute registers an empty route.
+func (r *Router) NewRoute() *Route {
+	route := &Route{router: r, methods: append([]string(nil), r.methods...)}
+
+	top := r.top()
+	top.mu.Lock()
+	top.routes = append(top.routes, route)
+	top.compiled = nil
+	top.mu.Unlock()
+
+	return route
+}
+
+// Handle registers a new route with a matcher for the URL path.
+func (r *Router) Handle(path string, handler http.Handler) *Route {
+	return r.NewRoute().Path(path).Handler(handler)
+}
+
+// HandleFunc registers a new route with a matcher for the URL path.
+func (r *Router) HandleFunc(path string, f func(http.ResponseWriter, *http.Request)) *Route {
+	return r.NewRoute().Path(path).HandlerFunc(f)
+}
+
+// Path registers a new route with a matcher for the URL path.
+func (r *Router) Path(tpl string) *Route {
+	return r.NewRoute().Path(tpl)
+}
+
+// PathPrefix registers a new route with a matcher for the URL path prefix.
+func (r *Router) PathPrefix(tpl string) *Route {
+	return r.NewRoute().PathPrefix(tpl)
+}
+
+// Methods registers a new route with a matcher for HTTP methods.
+func (r *Router) Methods(methods ...string) *Route {
+	return r.NewRoute().Methods(methods...)
+}
+
+// Get returns a route registered with the given name.
+func (r *Router) Get(name string) *Route {
+	top := r.top()
+	top.mu.Lock()
+	defer top.mu.Unlock()
+
+	for _, route := range top.routes {
+		if route.name == name {
+			return route
+		}
+	}
+
+	return nil
+}
+
+// Use appends a MiddlewareFunc to the chain. Middleware is executed when
+// a route of this router or one of its subrouters matches.
+func (r *Router) Use(mwf ...MiddlewareFunc) {
+	r.middlewares = append(r.middlewares, mwf...)
+	r.top().changed()
+}
+
+func (r *Router) top() *Router {
+	for r.parent != nil {
+		r = r.parent
+	}
+
+	return r
+}
+
+func (r *Router) changed() {
+	r.mu.Lock()
+	r.compiled = nil
+	r.mu.Unlock()
+}
+
+// chain returns the middleware of r and its parents, outermost first.
+func (r *Router) chain() []router.Middleware {
+	var chain []router.Middleware
+
+	if r.parent != nil {
+		chain = r.parent.chain()
+	}
+
+	for _, mwf := range r.middlewares {
+		chain = append(chain, router.StdMiddleware(mwf))
+	}
+
+	return chain
+}
+
+func (r *Router) compile() *router.Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.compiled != nil {
+		return r.compiled
+	}
+
+	rtr := router.New()
+	rtr.NotFound = r.NotFoundHandler
+	rtr.MethodNotAllowed = r.MethodNotAllowedHandler
+
+	// the first route registered wins, so it is added last
+	for i := len(r.routes) - 1; i >= 0; i-- {
+		r.routes[i].register(rtr)
+	}
+
+	r.compiled = rtr
+
+	return rtr
+}
+
+// Route stores information to match a request and build URLs.
+type Route struct {
+	router  *Router
+	handler http.Handler
+	tpl     string
+	prefix  bool
+	methods []string
+	name    string
+}
+
+// Path adds a matcher for the URL path.
+func (r *Route) Path(tpl string) *Route {
+	r.tpl = r.router.prefix + tpl
+	r.prefix = false
+	r.changed()
+
+	return r
+}
+
+// PathPrefix adds a matcher for the URL path prefix.
+func (r *Route) PathPrefix(tpl string) *Route {
+	r.tpl = r.router.prefix + tpl
+	r.prefix = true
+	r.changed()
+
+	return r
+}
+
+// Methods adds a matcher for HTTP methods.
+func (r *Route) Methods(methods ...string) *Route {
+	for _, method := range methods {
+		r.methods = append(r.methods, strings.ToUpper(method))
+	}
+	r.changed()
+
+	return r
+}
+
+// Handler sets a handler for the route.
+func (r *Route) Handler(handler http.Handler) *Route {
+	r.handler = handler
+	r.changed()
+
+	return r
+}
+
+// HandlerFunc sets a handler function for the route.
+func (r *Route) HandlerFunc(f func(http.ResponseWriter, *http.Request)) *Route {
+	return r.Handler(http.HandlerFunc(f))
+}
+
+// GetHandler returns the handler for the route, if any.
+func (r *Route) GetHandler() http.Handler {
+	return r.handler
+}
+
+// Name sets the name for the route, used to build URLs.
+func (r *Route) Name(name string) *Route {
+	r.name = name
+
+	return r
+}
+
+// GetName returns the name for the route, if any.
+func (r *Route) GetName() string {
+	return r.name
+}
+
+// GetPathTemplate returns the template used to build the route match.
+func (r *Route) GetPathTemplate() (string, error) {
+	return r.tpl, nil
+}
+
+// Subrouter creates a subrouter for the route. Routes of the subrouter are
+// prefixed with the path of the route and inherit its methods.
+func (r *Route) Subrouter() *Router {
+	return &Router{
+		parent:  r.router,
+		prefix:  r.tpl,
+		methods: append([]string(nil), r.methods...),
+	}
+}
+
+func (r *Route) changed() {
+	r.router.top().changed()
+}
+
+func (r *Route) register(rtr *router.Router) {
+	if r.handler == nil || r.tpl == "" {
+		return
+	}
+
+	paths := []string{brace.Translate(r.tpl)}
+
+	if r.prefix {
+		if strings.HasSuffix(paths[0], "/") {
+			paths[0] += "*"
+		} else {
+			paths = append(paths, paths[0]+"/*")
+		}
+	}
+
+	methods := r.methods
+	if len(methods) == 0 {
+		methods = []string{""}
+	}
+
+	chain := r.router.chain()
+
+	for _, path := range paths {
+		for _, method := range methods {
+			rtr.Handle(method, path, r.handler, chain...)
+		}
+	}
+}
+
+// MiddlewareFunc is a function which receives an http.Handler and returns
+// another http.Handler. Typically, the returned handler is a closure which
+// does something with the http.ResponseWriter and http.Request passed to it,
+// and then calls the handler passed as parameter to the MiddlewareFunc.
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// Middleware allows MiddlewareFunc to implement the middleware interface.
+func (mw MiddlewareFunc) Middleware(handler http.Handler) http.Handler {
+	return mw(handler)
+}
+
+// Vars returns the route variables for the current request, if any.
+func Vars(r *http.Request) map[string]string {
+	params := router.ParamsFromContext(r.Context())
+	if params == nil {
+		return nil
+	}
+
+	vars := make(map[string]string, len(params))
+	for _, p := range params {
+		vars[p.Key] = p.Value
+	}
+
+	return vars
+}
@@ -0,0 +1,161 @@
+package mux
+
+import (
+	"github.com/shyamz-22/router/assert"
+	"github.com/shyamz-22/router/fixture"
+
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+	rtr := NewRouter()
+	rtr.HandleFunc("/products/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "product %s", Vars(r)["id"])
+	}).Methods("get").Name("product")
+
+	rtr.HandleFunc("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first"))
+	}).Methods(http.MethodPut)
+
+	rtr.HandleFunc("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("second"))
+	}).Methods(http.MethodPut)
+
+	rtr.PathPrefix("/static/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("static " + r.URL.Path))
+	})
+
+	api := rtr.PathPrefix("/api").Methods(http.MethodPost).Subrouter()
+	api.HandleFunc("/users/{user}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("user " + Vars(r)["user"]))
+	})
+
+	rtr.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	for _, tc := range []struct {
+		method, path string
+		status       int
+		body         string
+	}{
+		{http.MethodGet, "/products/42", http.StatusOK, "product 42"},
+		{http.MethodPut, "/products/42", http.StatusOK, "first"},
+		{http.MethodDelete, "/static/css/site.css", http.StatusOK, "static /static/css/site.css"},
+		{http.MethodPost, "/api/users/gopher", http.StatusOK, "user gopher"},
+		{http.MethodGet, "/api/users/gopher", http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "/products/gopher", http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "/unknown", http.StatusTeapot, ""},
+	} {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(tc.method, tc.path, nil)
+
+			rtr.ServeHTTP(w, r)
+
+			assert.ResponseWithBody(t, w, tc.status, tc.body)
+		})
+	}
+
+	if tpl, _ := rtr.Get("product").GetPathTemplate(); tpl != "/products/{id:[0-9]+}" {
+		t.Fatalf("\nExpected: /products/{id:[0-9]+}\nActual:%s\n", tpl)
+	}
+}
+
+func TestRouterWithGithubRoutes(t *testing.T) {
+	t.Parallel()
+	rtr := NewRouter()
+	for _, route := range fixture.MuxRoutes {
+		rtr.HandleFunc(route.Path, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}).Methods(route.Method)
+	}
+
+	for _, route := range fixture.RoutesWithPathValues {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(route.Method, route.Path, nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusOK)
+	}
+}
+
+func TestRouterWithMiddleware(t *testing.T) {
+	t.Parallel()
+	header := func(key, value string) MiddlewareFunc {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add(key, value)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	rtr := NewRouter()
+	rtr.HandleFunc("/products", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("products"))
+	})
+
+	api := rtr.PathPrefix("/api").Subrouter()
+	api.Use(header("X-Trace", "api"))
+	api.HandleFunc("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(Vars(r)["id"]))
+	})
+
+	rtr.Use(header("X-Trace", "root"))
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/api/products/1", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	assert.ResponseWithBody(t, w, http.StatusOK, "1")
+	if trace := w.Result().Header["X-Trace"]; len(trace) != 2 || trace[0] != "root" || trace[1] != "api" {
+		t.Fatalf("\nExpected: [root api]\nActual:%v\n", trace)
+	}
+
+	w = httptest.NewRecorder()
+	r, _ = http.NewRequest(http.MethodGet, "/products", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	assert.ResponseWithBody(t, w, http.StatusOK, "products")
+	assert.ResponseWithHeader(t, w, "X-Trace", "root")
+}
+
+func TestRouterWithSubrouterMethods(t *testing.T) {
+	t.Parallel()
+	rtr := NewRouter()
+	api := rtr.PathPrefix("/api").Methods(http.MethodGet, http.MethodPost, http.MethodPatch).Subrouter()
+
+	api.Path("/a").Methods(http.MethodPut).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("a"))
+	})
+	api.Path("/b").Methods(http.MethodDelete).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("b"))
+	})
+
+	for _, tc := range []struct {
+		method, path string
+		status       int
+	}{
+		{http.MethodPut, "/api/a", http.StatusOK},
+		{http.MethodDelete, "/api/a", http.StatusMethodNotAllowed},
+		{http.MethodDelete, "/api/b", http.StatusOK},
+		{http.MethodPut, "/api/b", http.StatusMethodNotAllowed},
+	} {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(tc.method, tc.path, nil)
+
+			rtr.ServeHTTP(w, r)
+
+			assert.ResponseWithStatus(t, w, tc.status)
+		})
+	}
+}
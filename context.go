@@ -0,0 +1,60 @@
+package router
+
+import (
+	"context"
+	"net/http"
+)
+
+type paramsKey struct{}
+
+// paramsContext carries the path params of a request. It holds the params
+// itself instead of using context.WithValue, which saves an allocation per
+// request.
+type paramsContext struct {
+	context.Context
+	params PathParams
+}
+
+func (c *paramsContext) Value(key interface{}) interface{} {
+	if key == (paramsKey{}) {
+		return c
+	}
+
+	return c.Context.Value(key)
+}
+
+// ParamsFromContext returns the path params of the route that matched the
+// request of ctx, or nil if there are none. The params are stored for
+// http.Handler routes and StdMiddleware only, as HandlerFuncWithParam routes
+// receive them as an argument and should not pay for the copy of the request.
+func ParamsFromContext(ctx context.Context) PathParams {
+	if c, ok := ctx.Value(paramsKey{}).(*paramsContext); ok {
+		return c.params
+	}
+
+	return nil
+}
+
+// withParams returns a shallow copy of request carrying params in its
+// context and as path values, or request itself if it carries them already.
+func withParams(request *http.Request, params PathParams) *http.Request {
+	if c, ok := request.Context().Value(paramsKey{}).(*paramsContext); ok && sameParams(c.params, params) {
+		return request
+	}
+
+	request = request.WithContext(&paramsContext{
+		Context: request.Context(),
+		params:  params,
+	})
+
+	for _, p := range params {
+		request.SetPathValue(p.Key, p.Value)
+	}
+
+	return request
+}
+
+// sameParams reports whether a and b are the same slice.
+func sameParams(a, b PathParams) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
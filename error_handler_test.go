@@ -0,0 +1,135 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var errPingGone = ErrorWithStatus(http.StatusGone, errors.New("ping is gone"))
+
+func TestRouteWithErrorHandler(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	failing := func(err error) HandlerFuncWithError {
+		return func(w http.ResponseWriter, r *http.Request, params PathParams) error {
+			return err
+		}
+	}
+
+	rtr.AddWithError("/pings/:id", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) error {
+		id, err := params.Int("id")
+		if err != nil {
+			return err
+		}
+
+		if id == 0 {
+			return errPingGone
+		}
+
+		w.Write(pong)
+		return nil
+	})
+
+	rtr.AddWithError("/crash", http.MethodGet, failing(errors.New("connection refused")))
+
+	api := rtr.Group("/api")
+	api.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		w.WriteHeader(statusOf(err))
+		w.Write([]byte(`{"error":"` + err.Error() + `"}`))
+	}
+	api.Group("/v1").AddWithError("/pings", http.MethodGet, failing(errPingGone))
+
+	t.Run("does not interfere with successful handlers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings/1", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "Pong!")
+	})
+
+	t.Run("answers errors with their status", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings/0", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusGone, "ping is gone\n")
+	})
+
+	t.Run("answers param errors with bad request", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings/x", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusBadRequest, "path param \"id\": \"x\" is not a valid int\n")
+	})
+
+	t.Run("hides internal errors", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/crash", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusInternalServerError, "Internal Server Error\n")
+	})
+
+	t.Run("uses the error handler of the group", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/api/v1/pings", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusGone, `{"error":"ping is gone"}`)
+	})
+}
+
+func TestRouteWithCustomErrorHandler(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		w.WriteHeader(http.StatusTeapot)
+	}
+
+	rtr.AddWithError("/pings", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) error {
+		return errPingGone
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/pings", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	assert.ResponseWithStatus(t, w, http.StatusTeapot)
+}
+
+func TestRouteWithErrorAfterPartialResponse(t *testing.T) {
+	t.Parallel()
+
+	for name, panicHandler := range map[string]func(http.ResponseWriter, *http.Request, interface{}){
+		"with panic handler":    DefaultPanicHandler,
+		"without panic handler": nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rtr := New()
+			rtr.PanicHandler = panicHandler
+			rtr.AddWithError("/pings", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) error {
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte("pong"))
+				return errPingGone
+			})
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodGet, "/pings", nil)
+
+			rtr.ServeHTTP(w, r)
+
+			assert.ResponseWithBody(t, w, http.StatusAccepted, "pong")
+		})
+	}
+}
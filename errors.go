@@ -0,0 +1,266 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// RouteError describes a request that did not match any route. It is passed
+// to NotFound and MethodNotAllowed handlers through the request context.
+type RouteError struct {
+	Status  int
+	Method  string
+	Path    string
+	Allowed []string
+}
+
+func (e *RouteError) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Method, e.Path, strings.ToLower(http.StatusText(e.Status)))
+}
+
+// StatusCode returns the HTTP status of the error.
+func (e *RouteError) StatusCode() int {
+	return e.Status
+}
+
+type routeErrorKey struct{}
+
+// RouteErrorFromContext returns the RouteError of a request handed to a
+// NotFound or MethodNotAllowed handler.
+func RouteErrorFromContext(ctx context.Context) (*RouteError, bool) {
+	e, ok := ctx.Value(routeErrorKey{}).(*RouteError)
+	return e, ok
+}
+
+// errorScope holds NotFound and MethodNotAllowed overrides for all paths
+// below prefix.
+type errorScope struct {
+	prefix           string
+	notFound         http.Handler
+	methodNotAllowed http.Handler
+}
+
+// NotFoundFor sets the handler for unmatched paths below prefix. It takes
+// precedence over Router.NotFound and over handlers set for shorter prefixes.
+func (rtr *Router) NotFoundFor(prefix string, handler http.Handler) {
+	rtr.scope(prefix).notFound = handler
+}
+
+// MethodNotAllowedFor sets the handler for paths below prefix that match a
+// route with another method. It takes precedence over Router.MethodNotAllowed
+// and over handlers set for shorter prefixes.
+func (rtr *Router) MethodNotAllowedFor(prefix string, handler http.Handler) {
+	rtr.scope(prefix).methodNotAllowed = handler
+}
+
+func (rtr *Router) scope(prefix string) *errorScope {
+	_, prefix = parsePattern(strings.TrimSuffix(prefix, sep))
+
+	for _, s := range rtr.scopes {
+		if s.prefix == prefix {
+			return s
+		}
+	}
+
+	s := &errorScope{prefix: prefix}
+	rtr.scopes = append(rtr.scopes, s)
+
+	// longest prefix first
+	sort.SliceStable(rtr.scopes, func(i, j int) bool {
+		return len(rtr.scopes[i].prefix) > len(rtr.scopes[j].prefix)
+	})
+
+	return s
+}
+
+// errorHandler picks the handler for a route error, most specific scope first.
+func (rtr *Router) errorHandler(e *RouteError) http.Handler {
+	for _, s := range rtr.scopes {
+		if !hasPathPrefix(e.Path, s.prefix) {
+			continue
+		}
+
+		if e.Status == http.StatusMethodNotAllowed && s.methodNotAllowed != nil {
+			return s.methodNotAllowed
+		}
+
+		if e.Status == http.StatusNotFound && s.notFound != nil {
+			return s.notFound
+		}
+	}
+
+	if e.Status == http.StatusMethodNotAllowed {
+		return rtr.MethodNotAllowed
+	}
+
+	return rtr.NotFound
+}
+
+// hasPathPrefix reports whether path is prefix or below it. Path params of
+// the prefix match any segment.
+func hasPathPrefix(path, prefix string) bool {
+	for len(prefix) > 0 {
+		if len(path) == 0 || path[0] != sepChar || prefix[0] != sepChar {
+			return false
+		}
+
+		var part, prefixPart string
+		part, path = nextSegment(path[1:])
+		prefixPart, prefix = nextSegment(prefix[1:])
+
+		switch {
+		case isCatchAll(prefixPart):
+			return true
+		case isParam(prefixPart):
+			continue
+		case part != prefixPart:
+			return false
+		}
+	}
+
+	return true
+}
+
+// nextSegment splits path at its first slash, which stays with the rest.
+func nextSegment(path string) (segment, rest string) {
+	if i := strings.IndexByte(path, sepChar); i >= 0 {
+		return path[:i], path[i:]
+	}
+
+	return path, ""
+}
+
+// StatusCoder is implemented by responses and errors that choose their HTTP
+// status, such as RouteError.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// statusError is an error answered with status.
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+func (e *statusError) StatusCode() int {
+	return e.status
+}
+
+// ErrorWithStatus returns err carrying the HTTP status it is answered with.
+func ErrorWithStatus(status int, err error) error {
+	return &statusError{status: status, err: err}
+}
+
+// statusOf returns the HTTP status err is answered with.
+func statusOf(err error) int {
+	var (
+		coder    StatusCoder
+		bindErrs BindErrors
+		paramErr *ParamError
+	)
+
+	switch {
+	case errors.As(err, &coder):
+		return coder.StatusCode()
+	case errors.As(err, &bindErrs), errors.As(err, &paramErr):
+		return http.StatusBadRequest
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// HandlerFuncWithError is a request handle that returns its errors instead
+// of writing them. They are answered by the ErrorHandler of its group or
+// Router.
+type HandlerFuncWithError func(w http.ResponseWriter, request *http.Request, params PathParams) error
+
+// DefaultErrorHandler answers with the status of err, see StatusCoder, and
+// its message. Server errors are logged and answered with the status text
+// only. Nothing is written if the handler already sent the response headers.
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	status, message := errorResponse(r, err)
+
+	if headerWritten(w) {
+		return
+	}
+
+	http.Error(w, message, status)
+}
+
+// errorResponse returns the status err is answered with and the message for
+// the client. Server errors are logged and answered with the status text
+// only, so internals do not leak.
+func errorResponse(r *http.Request, err error) (int, string) {
+	status := statusOf(err)
+
+	if status >= http.StatusInternalServerError {
+		logError(r, err)
+		return status, http.StatusText(status)
+	}
+
+	return status, err.Error()
+}
+
+func logError(r *http.Request, err error) {
+	slog.ErrorContext(r.Context(), "router: error serving request",
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Any("error", err),
+	)
+}
+
+// AddWithError registers a new request handle returning errors with the
+// given path and method. See Add.
+func (rtr *Router) AddWithError(path string, method string, handler HandlerFuncWithError, middleware ...Middleware) *Route {
+	route := rtr.add(path, method, nil, middleware)
+	route.handler = handleErrors(rtr, route, handler)
+	rtr.compose(route)
+
+	return route
+}
+
+func handleErrors(rtr *Router, route *Route, handler HandlerFuncWithError) HandlerFuncWithParam {
+	return func(w http.ResponseWriter, request *http.Request, params PathParams) {
+		// error handlers must know whether the handler sent the headers
+		if !tracksHeader(w) {
+			sw := newStatusWriter(w)
+			defer releaseStatusWriter(sw)
+			w = sw.wrap()
+		}
+
+		if err := handler(w, request, params); err != nil {
+			rtr.errorHandlerOf(route, DefaultErrorHandler)(w, request, err)
+		}
+	}
+}
+
+// errorHandlerOf returns the ErrorHandler of the innermost group of route
+// that has one, else the one of the router, else fallback.
+func (rtr *Router) errorHandlerOf(route *Route, fallback func(http.ResponseWriter, *http.Request, error)) func(http.ResponseWriter, *http.Request, error) {
+	for g := route.group; g != nil; g = g.parent {
+		if g.ErrorHandler != nil {
+			return g.ErrorHandler
+		}
+	}
+
+	if rtr.ErrorHandler != nil {
+		return rtr.ErrorHandler
+	}
+
+	return fallback
+}
@@ -0,0 +1,112 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRouteWithNotFoundHandlers(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddGet("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pong)
+	})
+
+	rtr.AddGet("/api/pings/:id", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pong)
+	})
+
+	rtr.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e, _ := RouteErrorFromContext(r.Context())
+		w.WriteHeader(e.Status)
+		fmt.Fprintf(w, "<h1>%s not found</h1>", e.Path)
+	})
+
+	rtr.NotFoundFor("/api", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e, _ := RouteErrorFromContext(r.Context())
+		w.WriteHeader(e.StatusCode())
+		fmt.Fprintf(w, `{"path":%q}`, e.Path)
+	}))
+
+	t.Run("uses the router handler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pongs", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, "<h1>/pongs not found</h1>")
+	})
+
+	t.Run("uses the handler of the prefix", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/api/pongs", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, `{"path":"/api/pongs"}`)
+	})
+
+	t.Run("matches the prefix on segments only", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/apis", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, "<h1>/apis not found</h1>")
+	})
+}
+
+func TestRouteWithMethodNotAllowedHandlers(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddGet("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pong)
+	})
+
+	rtr.AddPost("/api/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	rtr.MethodNotAllowed = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e, _ := RouteErrorFromContext(r.Context())
+		w.WriteHeader(e.Status)
+		fmt.Fprintf(w, "%s %s: use %s", e.Method, e.Path, strings.Join(e.Allowed, " or "))
+	})
+
+	rtr.MethodNotAllowedFor("/api/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		fmt.Fprintf(w, `{"allow":%q}`, w.Header().Get("Allow"))
+	}))
+
+	t.Run("uses the router handler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodDelete, "/pings", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusMethodNotAllowed, "DELETE /pings: use GET or HEAD or OPTIONS")
+	})
+
+	t.Run("uses the handler of the prefix", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/api/pings", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusMethodNotAllowed, `{"allow":"OPTIONS, POST"}`)
+	})
+
+	t.Run("keeps the default for not found paths", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/api/pongs", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, "")
+	})
+}
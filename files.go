@@ -0,0 +1,216 @@
+package router
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+// ServeFiles serves the files of fsys, e.g. an embed.FS, below path, which
+// has to end in a named catch-all:
+//
+//	rtr.ServeFiles("/static/*filepath", os.DirFS("public"))
+//
+// A request for "/static/css/app.css" serves "css/app.css" of fsys.
+// Directories are not listed, use a FileServer for more control.
+func (rtr *Router) ServeFiles(path string, fsys fs.FS, middleware ...Middleware) *Route {
+	_, routePath := parsePattern(path)
+	name := routePath[strings.LastIndexByte(routePath, sepChar)+1:]
+
+	if !isCatchAll(name) || len(name) < 2 {
+		panic(fmt.Sprintf("Invalid Path: %s. Path must end with a named catch-all, e.g. /*filepath\n", path))
+	}
+
+	return rtr.Add(path, http.MethodGet, (&FileServer{FS: fsys}).handle(name[1:]), middleware...)
+}
+
+// FileServer serves the files of FS with their Content-Type, ETag and
+// Last-Modified headers, and answers conditional and range requests. Where
+// a file has a ".gz" sibling, it is sent instead to clients accepting gzip.
+//
+// As http.Handler, it serves the file at the request path, which makes it
+// suitable for Mount.
+type FileServer struct {
+	FS fs.FS
+
+	// ListDirectories enables listings of directories without an
+	// index.html. They are answered with 404 otherwise.
+	ListDirectories bool
+
+	// hashes caches the ETags of files without a modification time.
+	hashes sync.Map
+}
+
+func (fsrv *FileServer) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	fsrv.serveFile(w, request, request.URL.Path)
+}
+
+// handle serves the file named by the catch-all param key.
+func (fsrv *FileServer) handle(key string) HandlerFuncWithParam {
+	return func(w http.ResponseWriter, request *http.Request, params PathParams) {
+		fsrv.serveFile(w, request, params.ByName(key))
+	}
+}
+
+func (fsrv *FileServer) serveFile(w http.ResponseWriter, request *http.Request, name string) {
+	name = strings.TrimPrefix(path.Clean(sep+name), sep)
+	if name == "" {
+		name = "."
+	}
+
+	info, err := fs.Stat(fsrv.FS, name)
+	if err != nil {
+		fileError(w, err)
+		return
+	}
+
+	if info.IsDir() {
+		fsrv.serveDir(w, request, name)
+		return
+	}
+
+	fsrv.serveContent(w, request, name, info)
+}
+
+func (fsrv *FileServer) serveDir(w http.ResponseWriter, request *http.Request, name string) {
+	index := path.Join(name, "index.html")
+	info, err := fs.Stat(fsrv.FS, index)
+
+	if err != nil && !fsrv.ListDirectories {
+		fileError(w, fs.ErrNotExist)
+		return
+	}
+
+	// relative links of the directory need its trailing slash. The location
+	// stays relative, as the request path may be stripped by Mount.
+	if !strings.HasSuffix(request.URL.Path, sep) {
+		w.Header().Set("Location", path.Base(request.URL.Path)+sep)
+		w.WriteHeader(http.StatusMovedPermanently)
+		return
+	}
+
+	if err == nil {
+		fsrv.serveContent(w, request, index, info)
+		return
+	}
+
+	entries, err := fs.ReadDir(fsrv.FS, name)
+	if err != nil {
+		fileError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintln(w, "<pre>")
+	for _, entry := range entries {
+		entryName := entry.Name()
+		if entry.IsDir() {
+			entryName += sep
+		}
+
+		link := url.URL{Path: entryName}
+		fmt.Fprintf(w, "<a href=\"%s\">%s</a>\n", html.EscapeString(link.String()), html.EscapeString(entryName))
+	}
+	fmt.Fprintln(w, "</pre>")
+}
+
+func (fsrv *FileServer) serveContent(w http.ResponseWriter, request *http.Request, name string, info fs.FileInfo) {
+	header := w.Header()
+
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	if gzInfo, err := fs.Stat(fsrv.FS, name+".gz"); err == nil && !gzInfo.IsDir() {
+		header.Add("Vary", "Accept-Encoding")
+
+		if acceptsGzip(request) {
+			header.Set("Content-Encoding", "gzip")
+			name, info = name+".gz", gzInfo
+		}
+	}
+
+	f, err := fsrv.FS.Open(name)
+	if err != nil {
+		fileError(w, err)
+		return
+	}
+	defer f.Close()
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		b, err := io.ReadAll(f)
+		if err != nil {
+			fileError(w, err)
+			return
+		}
+		content = bytes.NewReader(b)
+	}
+
+	etag, err := fsrv.etag(name, info, content)
+	if err != nil {
+		fileError(w, err)
+		return
+	}
+	header.Set("ETag", etag)
+
+	http.ServeContent(w, request, name, info.ModTime(), content)
+}
+
+// etag derives the ETag of a file from its modification time and size. Files
+// without modification time, as those of an embed.FS, are hashed once.
+func (fsrv *FileServer) etag(name string, info fs.FileInfo, content io.ReadSeeker) (string, error) {
+	if !info.ModTime().IsZero() {
+		return fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()), nil
+	}
+
+	if etag, ok := fsrv.hashes.Load(name); ok {
+		return etag.(string), nil
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	etag := fmt.Sprintf(`"%x"`, hash.Sum(nil)[:16])
+	fsrv.hashes.Store(name, etag)
+
+	return etag, nil
+}
+
+func acceptsGzip(request *http.Request) bool {
+	for _, encoding := range strings.Split(request.Header.Get("Accept-Encoding"), ",") {
+		encoding, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.TrimSpace(encoding) == "gzip" && strings.ReplaceAll(params, " ", "") != "q=0" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func fileError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, fs.ErrNotExist), errors.Is(err, fs.ErrInvalid):
+		http.Error(w, "404 page not found", http.StatusNotFound)
+	case errors.Is(err, fs.ErrPermission):
+		http.Error(w, "403 Forbidden", http.StatusForbidden)
+	default:
+		http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,147 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestRouteWithFiles(t *testing.T) {
+	t.Parallel()
+	modified := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	rtr := New()
+	rtr.ServeFiles("/static/*filepath", fstest.MapFS{
+		"css/app.css":    {Data: []byte("body{}"), ModTime: modified},
+		"js/app.js":      {Data: []byte("console.log(1)"), ModTime: modified},
+		"js/app.js.gz":   {Data: []byte("gzipped"), ModTime: modified},
+		"docs/readme":    {Data: []byte("0123456789")},
+		"docs/index.htm": {Data: []byte("no index")},
+	})
+
+	rtr.Mount("/browse", &FileServer{
+		FS: fstest.MapFS{
+			"a.txt":   {Data: []byte("a")},
+			"sub/b&c": {Data: []byte("b")},
+		},
+		ListDirectories: true,
+	})
+
+	t.Run("serves files with content type and last modified", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/static/css/app.css", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "body{}")
+		assert.ResponseWithHeader(t, w, "Content-Type", "text/css; charset=utf-8")
+		assert.ResponseWithHeader(t, w, "Last-Modified", "Fri, 01 Mar 2024 12:00:00 GMT")
+		assert.ResponseWithHeader(t, w, "ETag", `"17b8a23358908000-6"`)
+	})
+
+	t.Run("answers conditional requests", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/static/css/app.css", nil)
+		r.Header.Set("If-None-Match", `"17b8a23358908000-6"`)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNotModified)
+	})
+
+	t.Run("hashes files without modification time", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/static/docs/readme", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "0123456789")
+		assert.ResponseWithHeader(t, w, "ETag", `"84d89877f0d4041efb6bf91a16f0248f"`)
+		assert.ResponseWithHeader(t, w, "Last-Modified", "")
+	})
+
+	t.Run("answers range requests", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/static/docs/readme", nil)
+		r.Header.Set("Range", "bytes=2-4")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusPartialContent, "234")
+		assert.ResponseWithHeader(t, w, "Content-Range", "bytes 2-4/10")
+	})
+
+	t.Run("serves precompressed files to clients accepting gzip", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/static/js/app.js", nil)
+		r.Header.Set("Accept-Encoding", "br, gzip")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "gzipped")
+		assert.ResponseWithHeader(t, w, "Content-Encoding", "gzip")
+		assert.ResponseWithHeader(t, w, "Content-Type", "text/javascript; charset=utf-8")
+		assert.ResponseWithHeader(t, w, "Vary", "Accept-Encoding")
+	})
+
+	t.Run("serves plain files to other clients", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/static/js/app.js", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "console.log(1)")
+		assert.ResponseWithHeader(t, w, "Content-Encoding", "")
+		assert.ResponseWithHeader(t, w, "Vary", "Accept-Encoding")
+	})
+
+	t.Run("does not list directories by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/static/docs/", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNotFound)
+	})
+
+	t.Run("does not leave the file system", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/static/../../etc/passwd", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNotFound)
+	})
+
+	t.Run("lists directories if enabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/browse/", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "<pre>\n<a href=\"a.txt\">a.txt</a>\n<a href=\"sub/\">sub/</a>\n</pre>\n")
+	})
+
+	t.Run("redirects directories to their trailing slash", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/browse/sub", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusMovedPermanently)
+		assert.ResponseWithHeader(t, w, "Location", "sub/")
+	})
+
+	t.Run("escapes listed names", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/browse/sub/", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "<pre>\n<a href=\"b&amp;c\">b&amp;c</a>\n</pre>\n")
+	})
+}
@@ -0,0 +1,206 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Registrar is implemented by Router and Group to register routes.
+type Registrar interface {
+	Add(path string, method string, handler HandlerFuncWithParam, middleware ...Middleware) *Route
+	Check(path string, method string) error
+	AddGet(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route
+	AddPost(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route
+	AddPut(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route
+	AddDelete(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route
+	AddOptions(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route
+	AddPatch(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route
+	AddHead(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route
+	Handle(method, path string, handler http.Handler, middleware ...Middleware) *Route
+	HandleFunc(method, path string, handler http.HandlerFunc, middleware ...Middleware) *Route
+	AddWithError(path string, method string, handler HandlerFuncWithError, middleware ...Middleware) *Route
+	Mount(prefix string, handler http.Handler, middleware ...Middleware) *Route
+	Group(prefix string, middleware ...Middleware) *Group
+	Use(middleware ...Middleware)
+}
+
+var (
+	_ Registrar = &Router{}
+	_ Registrar = &Group{}
+)
+
+// Group registers routes below a shared path prefix. Its middleware wraps
+// the routes of the group and of its nested groups, inside the middleware of
+// its parents.
+type Group struct {
+	// Meta is copied into the Meta of every route added to the group or its
+	// nested groups afterwards, after the Meta of the parent groups.
+	Meta Meta
+
+	// ErrorHandler answers the errors returned by HandlerFuncWithError and
+	// JSON routes of the group and of nested groups without an ErrorHandler of
+	// their own, instead of Router.ErrorHandler.
+	ErrorHandler func(w http.ResponseWriter, request *http.Request, err error)
+
+	rtr        *Router
+	parent     *Group
+	prefix     string
+	middleware []Middleware
+}
+
+// Group returns a group of routes below prefix, wrapped by the given
+// middleware. The prefix may contain path params, e.g. "/repos/:owner".
+func (rtr *Router) Group(prefix string, middleware ...Middleware) *Group {
+	return &Group{
+		Meta:       Meta{},
+		rtr:        rtr,
+		prefix:     strings.TrimSuffix(prefix, sep),
+		middleware: middleware,
+	}
+}
+
+// Group returns a nested group below the prefix of g.
+func (g *Group) Group(prefix string, middleware ...Middleware) *Group {
+	nested := g.rtr.Group(g.prefix+prefix, middleware...)
+	nested.parent = g
+
+	return nested
+}
+
+// Use appends middleware of the group. Routes already registered are
+// composed again.
+func (g *Group) Use(middleware ...Middleware) {
+	g.middleware = append(g.middleware, middleware...)
+
+	for _, route := range g.rtr.routeList {
+		g.rtr.compose(route)
+	}
+}
+
+// NotFound sets the handler for unmatched paths below the prefix of g.
+func (g *Group) NotFound(handler http.Handler) {
+	g.rtr.NotFoundFor(g.prefix, handler)
+}
+
+// MethodNotAllowed sets the handler for paths below the prefix of g that
+// match a route with another method.
+func (g *Group) MethodNotAllowed(handler http.Handler) {
+	g.rtr.MethodNotAllowedFor(g.prefix, handler)
+}
+
+// Add registers a new request handle with the given path below the prefix of
+// g and method. See Router.Add.
+func (g *Group) Add(path string, method string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return g.attach(g.rtr.add(joinPattern(g.prefix, path), method, handler, middleware))
+}
+
+// Check reports the invalid path or method Add panics on, with path below
+// the prefix of g. See Router.Check.
+func (g *Group) Check(path string, method string) error {
+	return g.rtr.Check(joinPattern(g.prefix, path), method)
+}
+
+// AddWithError registers a new request handle returning errors with the
+// given path below the prefix of g and method. See Router.AddWithError.
+func (g *Group) AddWithError(path string, method string, handler HandlerFuncWithError, middleware ...Middleware) *Route {
+	route := g.rtr.add(joinPattern(g.prefix, path), method, nil, middleware)
+	route.handler = handleErrors(g.rtr, route, handler)
+
+	return g.attach(route)
+}
+
+// Mount delegates all requests below prefix, itself below the prefix of g, to
+// handler. See Router.Mount.
+func (g *Group) Mount(prefix string, handler http.Handler, middleware ...Middleware) *Route {
+	return g.attach(g.rtr.mount(g.prefix+prefix, handler, middleware))
+}
+
+// attach makes route part of g and composes its handler chain.
+func (g *Group) attach(route *Route) *Route {
+	route.group = g
+
+	for _, meta := range g.metas() {
+		for key, value := range meta {
+			route.Set(key, value)
+		}
+	}
+
+	g.rtr.compose(route)
+
+	return route
+}
+
+// Handle registers a http.Handler with the given method and path below the
+// prefix of g. See Router.Handle.
+func (g *Group) Handle(method, path string, handler http.Handler, middleware ...Middleware) *Route {
+	return g.Add(path, method, serveHandler(handler), middleware...)
+}
+
+// HandleFunc registers a http.HandlerFunc with the given method and path
+// below the prefix of g.
+func (g *Group) HandleFunc(method, path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return g.Handle(method, path, handler, middleware...)
+}
+
+// AddGet registers a new request handle with the given path and Get-method.
+func (g *Group) AddGet(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return g.Add(path, http.MethodGet, handler, middleware...)
+}
+
+// AddPost registers a new request handle with the given path and Post-method.
+func (g *Group) AddPost(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return g.Add(path, http.MethodPost, handler, middleware...)
+}
+
+// AddPut registers a new request handle with the given path and Put-method.
+func (g *Group) AddPut(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return g.Add(path, http.MethodPut, handler, middleware...)
+}
+
+// AddDelete registers a new request handle with the given path and Delete-method.
+func (g *Group) AddDelete(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return g.Add(path, http.MethodDelete, handler, middleware...)
+}
+
+// AddOptions registers a new request handle with the given path and Options-method.
+func (g *Group) AddOptions(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return g.Add(path, http.MethodOptions, handler, middleware...)
+}
+
+// AddPatch registers a new request handle with the given path and Patch-method.
+func (g *Group) AddPatch(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return g.Add(path, http.MethodPatch, handler, middleware...)
+}
+
+// AddHead registers a new request handle with the given path and Head-method.
+func (g *Group) AddHead(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return g.Add(path, http.MethodHead, handler, middleware...)
+}
+
+// chain returns the middleware of g and its parents, outermost first.
+func (g *Group) chain() []Middleware {
+	if g == nil {
+		return nil
+	}
+
+	return append(g.parent.chain(), g.middleware...)
+}
+
+// metas returns the Meta of g and its parents, outermost first.
+func (g *Group) metas() []Meta {
+	if g == nil {
+		return nil
+	}
+
+	return append(g.parent.metas(), g.Meta)
+}
+
+// joinPattern puts prefix in front of the path of pattern, after its method
+// if it has one.
+func joinPattern(prefix, pattern string) string {
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		return pattern[:i+1] + prefix + strings.TrimLeft(pattern[i+1:], " \t")
+	}
+
+	return prefix + pattern
+}
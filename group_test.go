@@ -0,0 +1,100 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouteWithGroups(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.Use(trace("global"))
+
+	api := rtr.Group("/api/v1", trace("api"))
+	api.Meta["version"] = 1
+
+	repos := api.Group("/repos/:owner/:repo", trace("repos"))
+	repos.Meta["tag"] = "repos"
+	repos.AddGet("/pulls/:number", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte(params.ByName("owner") + "/" + params.ByName("repo") + "#" + params.ByName("number")))
+	}, trace("route"))
+
+	users := api.Group("/users")
+	users.Add("GET /{user}", "", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte(params.ByName("user")))
+	})
+
+	users.NotFound(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"no such user"}`))
+	}))
+
+	repos.MethodNotAllowed(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":"read only"}`))
+	}))
+
+	repos.Use(trace("late"))
+
+	t.Run("stacks prefixes and middleware", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/api/v1/repos/shyamz-22/router/pulls/1", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		route := "(/api/v1/repos/:owner/:repo/pulls/:number) "
+		assert.ResponseWithBody(t, w, http.StatusOK,
+			"global"+route+"api"+route+"repos"+route+"late"+route+"route"+route+"shyamz-22/router#1")
+	})
+
+	t.Run("accepts patterns with method", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/api/v1/users/gopher", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "global(/api/v1/users/:user) api(/api/v1/users/:user) gopher")
+	})
+
+	t.Run("uses the not found handler of the group", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/api/v1/users/gopher/repos", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, `{"error":"no such user"}`)
+	})
+
+	t.Run("uses the method not allowed handler of a group with params", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodPost, "/api/v1/repos/shyamz-22/router/pulls/1", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusMethodNotAllowed, `{"error":"read only"}`)
+	})
+
+	t.Run("keeps the default outside of groups", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/api/v1/orgs", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, "")
+	})
+
+	t.Run("stacks metadata", func(t *testing.T) {
+		for _, route := range rtr.Routes() {
+			if route.Meta["version"] != 1 {
+				t.Fatalf("\nExpected version: 1\nActual:%v\n", route.Meta["version"])
+			}
+		}
+
+		if tag := rtr.Routes()[0].Meta["tag"]; tag != "repos" {
+			t.Fatalf("\nExpected tag: repos\nActual:%v\n", tag)
+		}
+	})
+}
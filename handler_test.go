@@ -0,0 +1,100 @@
+package router
+
+import (
+	"fmt"
+	"github.com/shyamz-22/router/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouteWithHttpHandler(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.Handle(http.MethodGet, "/files", http.StripPrefix("/files", http.NotFoundHandler()))
+
+	rtr.HandleFunc(http.MethodGet, "/pings/:id", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(ParamsFromContext(r.Context()).ByName("id")))
+	})
+
+	rtr.HandleFunc(http.MethodGet, "/pings/:id/pongs/:pongId", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.PathValue("id") + "/" + r.PathValue("pongId")))
+	})
+
+	rtr.HandleFunc(http.MethodPost, "/pings", func(w http.ResponseWriter, r *http.Request) {
+		if ParamsFromContext(r.Context()) != nil {
+			t.Errorf("unexpected path params for a static route")
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	t.Run("serves a http.Handler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/files", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, "404 page not found\n")
+	})
+
+	t.Run("serves a http.HandlerFunc with path params", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings/Pong", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "Pong")
+	})
+
+	t.Run("serves a http.HandlerFunc with path values", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings/ping/pongs/pong", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "ping/pong")
+		if r.PathValue("id") != "" {
+			t.Fatalf("unexpected path value on the original request")
+		}
+	})
+
+	t.Run("serves a http.HandlerFunc without path params", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodPost, "/pings", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusCreated)
+	})
+}
+
+func TestRouteWithParamsInContext(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.HandleFunc(http.MethodGet, "/pings/:id", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(ParamsFromContext(r.Context()).ByName("id") + " " + r.PathValue("id")))
+	})
+	rtr.AddGet("/pongs/:id", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte(fmt.Sprint(ParamsFromContext(r.Context()))))
+	})
+
+	t.Run("stores the params of HEAD requests served by GET routes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodHead, "/pings/42", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusOK)
+		assert.ResponseWithHeader(t, w, "Content-Length", "5")
+	})
+
+	t.Run("leaves the context of HandlerFuncWithParam routes alone", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pongs/42", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "[]")
+	})
+}
@@ -0,0 +1,88 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouteWithAutomaticHead(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddGet("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Header().Set("X-Ping", r.Method)
+		w.Write(pong)
+	})
+
+	rtr.AddGet("/pongs", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pong)
+	})
+
+	rtr.AddHead("/pongs", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Header().Set("X-Pong", "head")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rtr.AddGet("/empty", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	t.Run("dispatches to the GET handler without body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodHead, "/pings", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "")
+		assert.ResponseWithHeader(t, w, "X-Ping", http.MethodHead)
+		assert.ResponseWithHeader(t, w, "Content-Length", "5")
+	})
+
+	t.Run("prefers an explicit HEAD route", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodHead, "/pongs", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusOK)
+		assert.ResponseWithHeader(t, w, "X-Pong", "head")
+	})
+
+	t.Run("keeps the status of the GET handler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodHead, "/empty", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNoContent)
+		assert.ResponseWithHeader(t, w, "Content-Length", "")
+	})
+
+	t.Run("returns 404 for unknown path", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodHead, "/unknown", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNotFound)
+	})
+}
+
+func TestRouteWithoutAutomaticHead(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.HandleHEAD = false
+	rtr.AddGet("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pong)
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodHead, "/pings", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	assert.ResponseWithStatus(t, w, http.StatusMethodNotAllowed)
+	assert.ResponseWithHeader(t, w, "Allow", "GET, OPTIONS")
+}
@@ -0,0 +1,254 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// MaxJSONBodyBytes limits the size of request bodies decoded by JSON.
+var MaxJSONBodyBytes int64 = 1 << 20
+
+// Validator is implemented by request types of JSON handlers that check
+// themselves once they are decoded. Errors without a status of their own
+// are answered with 422 Unprocessable Entity.
+type Validator interface {
+	Validate() error
+}
+
+// JSON adapts fn to a HandlerFuncWithParam exchanging JSON:
+//
+//	rtr.AddPost("/repos/:owner", router.JSON(func(ctx context.Context, req CreateRepo, params router.PathParams) (*Repo, error) {
+//		...
+//	}))
+//
+// The request body is decoded into Req, rejecting unknown fields, trailing
+// data and bodies above MaxJSONBodyBytes. Fields of Req tagged `path` or
+// `query` are bound as by PathParams.BindRequest, then Req is validated if it
+// implements Validator. JSON panics if the tags of Req are malformed, see
+// PathParams.Bind.
+//
+// The response is encoded with 200 OK, or the status of a response that
+// implements StatusCoder. A nil pointer response is answered with 204 No
+// Content. Errors go to the ErrorHandler of the route, see
+// Router.ErrorHandler. Without one they are answered with the status they
+// carry through StatusCoder, 400 Bad Request for invalid params and 500
+// Internal Server Error otherwise, and a body of the form
+// {"error": "message"}. Messages of server errors are logged, not sent.
+func JSON[Req, Resp any](fn func(ctx context.Context, req Req, params PathParams) (Resp, error)) HandlerFuncWithParam {
+	t := reflect.TypeOf((*Req)(nil)).Elem()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Struct {
+		if _, err := bindingOf(t); err != nil {
+			panic(fmt.Sprintf("Invalid JSON handler: %v\n", err))
+		}
+	}
+
+	handler := func(w http.ResponseWriter, request *http.Request, params PathParams) {
+		var req Req
+
+		if err := decodeJSON(w, request, params, &req); err != nil {
+			failJSON(w, request, err)
+			return
+		}
+
+		resp, err := fn(request.Context(), req, params)
+		if err == nil {
+			err = writeJSON(w, resp)
+		}
+
+		if err != nil {
+			failJSON(w, request, err)
+		}
+	}
+
+	jsonHandlers.Store(reflect.ValueOf(handler).Pointer(), true)
+
+	return handler
+}
+
+// jsonHandlers holds the code pointers of the handlers made by JSON, so the
+// router can tell them apart when they are registered, see handleJSONErrors.
+var jsonHandlers sync.Map
+
+func isJSONHandler(handler HandlerFuncWithParam) bool {
+	_, ok := jsonHandlers.Load(reflect.ValueOf(handler).Pointer())
+	return ok
+}
+
+// handleJSONErrors passes the errors of a JSON handler to the ErrorHandler of
+// route, as handleErrors does. Without one they are answered as JSON.
+func handleJSONErrors(rtr *Router, route *Route, handler HandlerFuncWithParam) HandlerFuncWithParam {
+	return func(w http.ResponseWriter, request *http.Request, params PathParams) {
+		sw := newStatusWriter(w)
+		defer releaseStatusWriter(sw)
+
+		sw.catching = true
+		handler(sw.wrap(), request, params)
+		sw.catching = false
+
+		if sw.err != nil {
+			rtr.errorHandlerOf(route, writeJSONError)(sw.wrap(), request, sw.err)
+		}
+	}
+}
+
+// failJSON hands err to the router, see handleJSONErrors, or answers it
+// itself if the handler is not served by a router.
+func failJSON(w http.ResponseWriter, request *http.Request, err error) {
+	for {
+		if sw, ok := w.(interface{ catch(error) bool }); ok && sw.catch(err) {
+			return
+		}
+
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			break
+		}
+
+		w = unwrapper.Unwrap()
+	}
+
+	writeJSONError(w, request, err)
+}
+
+func decodeJSON(w http.ResponseWriter, request *http.Request, params PathParams, dst interface{}) error {
+	if request.ContentLength != 0 && request.Body != nil && request.Body != http.NoBody {
+		if err := decodeBody(w, request, dst); err != nil {
+			return err
+		}
+	}
+
+	if target := bindTarget(dst); target != nil {
+		if err := params.BindRequest(request, target); err != nil {
+			return err
+		}
+	}
+
+	validator, ok := dst.(Validator)
+	if !ok {
+		validator, ok = reflect.ValueOf(dst).Elem().Interface().(Validator)
+	}
+
+	if ok {
+		if err := validator.Validate(); err != nil {
+			var coder StatusCoder
+			if !errors.As(err, &coder) {
+				err = &statusError{status: http.StatusUnprocessableEntity, err: err}
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decodeBody(w http.ResponseWriter, request *http.Request, dst interface{}) error {
+	mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+
+	if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+		return &statusError{
+			status: http.StatusUnsupportedMediaType,
+			err:    fmt.Errorf("content type %q is not JSON", mediaType),
+		}
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, request.Body, MaxJSONBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(dst)
+	if err == nil && decoder.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("body must contain a single JSON value")
+	}
+
+	var tooLarge *http.MaxBytesError
+
+	switch {
+	case err == nil, errors.Is(err, io.EOF):
+		return nil
+	case errors.As(err, &tooLarge):
+		return &statusError{
+			status: http.StatusRequestEntityTooLarge,
+			err:    fmt.Errorf("body must not be larger than %d bytes", tooLarge.Limit),
+		}
+	default:
+		return &statusError{status: http.StatusBadRequest, err: fmt.Errorf("invalid JSON body: %w", err)}
+	}
+}
+
+// bindTarget returns the struct pointer dst or *dst refers to, nil if the
+// request type is no struct.
+func bindTarget(dst interface{}) interface{} {
+	v := reflect.ValueOf(dst).Elem()
+
+	if v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		return v.Interface()
+	}
+
+	if v.Kind() == reflect.Struct {
+		return dst
+	}
+
+	return nil
+}
+
+// writeJSON encodes resp, or returns the error of encoding it without
+// writing anything.
+func writeJSON(w http.ResponseWriter, resp interface{}) error {
+	status := http.StatusOK
+
+	if v := reflect.ValueOf(resp); !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil() {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	if coder, ok := resp.(StatusCoder); ok {
+		status = coder.StatusCode()
+	}
+
+	if !bodyAllowed(status) {
+		w.WriteHeader(status)
+		return nil
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+
+	return nil
+}
+
+// writeJSONError answers err as DefaultErrorHandler does, with a body of the
+// form {"error": "message"}.
+func writeJSONError(w http.ResponseWriter, request *http.Request, err error) {
+	status, message := errorResponse(request, err)
+
+	if headerWritten(w) {
+		return
+	}
+
+	body, _ := json.Marshal(map[string]string{"error": message})
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
@@ -0,0 +1,197 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type createPing struct {
+	Owner   string `json:"-" path:"owner"`
+	Message string `json:"message"`
+}
+
+func (req createPing) Validate() error {
+	if req.Message == "" {
+		return errors.New("message is required")
+	}
+
+	return nil
+}
+
+type ping struct {
+	ID      int    `json:"id"`
+	Owner   string `json:"owner"`
+	Message string `json:"message"`
+}
+
+type created struct {
+	*ping
+}
+
+func (created) StatusCode() int {
+	return http.StatusCreated
+}
+
+func TestRouteWithJSONHandlers(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddPost("/pings/:owner", JSON(func(ctx context.Context, req createPing, params PathParams) (created, error) {
+		if req.Message == "fail" {
+			return created{}, errors.New("database is down")
+		}
+
+		if req.Message == "taken" {
+			return created{}, &RouteError{Status: http.StatusConflict, Method: http.MethodPost, Path: "/pings/" + req.Owner}
+		}
+
+		return created{&ping{ID: 1, Owner: req.Owner, Message: req.Message}}, nil
+	}))
+
+	rtr.AddGet("/pings/:id", JSON(func(ctx context.Context, req struct{}, params PathParams) (*ping, error) {
+		id, err := params.Int("id")
+		if id == 0 {
+			return nil, err
+		}
+
+		return &ping{ID: id, Message: "pong"}, err
+	}))
+
+	post := func(body, contentType string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodPost, "/pings/gopher", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+
+		rtr.ServeHTTP(w, r)
+
+		return w
+	}
+
+	t.Run("decodes requests and encodes responses with their status", func(t *testing.T) {
+		w := post(`{"message":"hello"}`, "application/json; charset=utf-8")
+
+		assert.ResponseWithBody(t, w, http.StatusCreated, `{"id":1,"owner":"gopher","message":"hello"}`+"\n")
+		assert.ResponseWithHeader(t, w, "Content-Type", "application/json; charset=utf-8")
+	})
+
+	t.Run("encodes responses of requests without body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings/7", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, `{"id":7,"owner":"","message":"pong"}`+"\n")
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		w := post(`{"message":"hello","admin":true}`, "application/json")
+
+		assert.ResponseWithBody(t, w, http.StatusBadRequest, `{"error":"invalid JSON body: json: unknown field \"admin\""}`+"\n")
+	})
+
+	t.Run("rejects trailing data", func(t *testing.T) {
+		w := post(`{"message":"hello"} {}`, "application/json")
+
+		assert.ResponseWithBody(t, w, http.StatusBadRequest, `{"error":"invalid JSON body: body must contain a single JSON value"}`+"\n")
+	})
+
+	t.Run("rejects other content types", func(t *testing.T) {
+		w := post(`message=hello`, "application/x-www-form-urlencoded")
+
+		assert.ResponseWithStatus(t, w, http.StatusUnsupportedMediaType)
+	})
+
+	t.Run("rejects large bodies", func(t *testing.T) {
+		w := post(`{"message":"`+strings.Repeat("a", int(MaxJSONBodyBytes))+`"}`, "application/json")
+
+		assert.ResponseWithBody(t, w, http.StatusRequestEntityTooLarge, `{"error":"body must not be larger than 1048576 bytes"}`+"\n")
+	})
+
+	t.Run("validates requests", func(t *testing.T) {
+		w := post(`{}`, "application/json")
+
+		assert.ResponseWithBody(t, w, http.StatusUnprocessableEntity, `{"error":"message is required"}`+"\n")
+	})
+
+	t.Run("maps errors with status", func(t *testing.T) {
+		w := post(`{"message":"taken"}`, "application/json")
+
+		assert.ResponseWithBody(t, w, http.StatusConflict, `{"error":"POST /pings/gopher: conflict"}`+"\n")
+	})
+
+	t.Run("maps param errors to bad request", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings/x", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusBadRequest, `{"error":"path param \"id\": \"x\" is not a valid int"}`+"\n")
+	})
+
+	t.Run("hides internal errors", func(t *testing.T) {
+		w := post(`{"message":"fail"}`, "application/json")
+
+		assert.ResponseWithBody(t, w, http.StatusInternalServerError, `{"error":"Internal Server Error"}`+"\n")
+	})
+}
+
+func TestRouteWithJSONErrorHandlers(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.ErrorHandler = func(w http.ResponseWriter, request *http.Request, err error) {
+		http.Error(w, "router: "+err.Error(), statusOf(err))
+	}
+
+	fail := JSON(func(ctx context.Context, req struct{}, params PathParams) (*ping, error) {
+		return nil, ErrorWithStatus(http.StatusTeapot, errors.New("no pings"))
+	})
+
+	rtr.AddGet("/pings", fail)
+
+	api := rtr.Group("/api")
+	api.ErrorHandler = func(w http.ResponseWriter, request *http.Request, err error) {
+		http.Error(w, "api: "+err.Error(), statusOf(err))
+	}
+	api.AddGet("/pings", fail)
+
+	t.Run("passes errors to the error handler of the router", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusTeapot, "router: no pings\n")
+	})
+
+	t.Run("passes errors to the error handler of the group", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/api/pings", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusTeapot, "api: no pings\n")
+	})
+}
+
+func TestJSONWithMalformedTags(t *testing.T) {
+	t.Parallel()
+	defer func() {
+		if recovered := recover(); recovered == nil || !strings.Contains(fmt.Sprint(recovered), "rule positive is not supported") {
+			t.Fatalf("Unexpected panic %v", recovered)
+		}
+	}()
+
+	type request struct {
+		ID int `path:"id" validate:"positive"`
+	}
+
+	JSON(func(ctx context.Context, req request, params PathParams) (*request, error) {
+		return &req, nil
+	})
+}
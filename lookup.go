@@ -0,0 +1,114 @@
+package router
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+)
+
+// Lookup returns the route a request for method and path is dispatched to
+// and its params, nil if there is none. As in ServeHTTP, HEAD requests fall
+// back to GET routes if HandleHEAD is set, and routes of patterns without
+// method serve all methods. Routes of mounted handlers are not looked into,
+// their mount point is returned.
+func (rtr *Router) Lookup(method, path string) (*Route, PathParams) {
+	if route, params := rtr.lookup(method, path); route != nil {
+		return route, params
+	}
+
+	if method == http.MethodHead && rtr.HandleHEAD {
+		if route, params := rtr.lookup(http.MethodGet, path); route != nil {
+			return route, params
+		}
+	}
+
+	return rtr.lookup("", path)
+}
+
+func (rtr *Router) lookup(method, path string) (*Route, PathParams) {
+	if root := rtr.routes[method]; root != nil {
+		if leaf, params := root.lookup(path); leaf != nil {
+			return leaf.route, params
+		}
+	}
+
+	return nil, nil
+}
+
+// Allowed returns the sorted list of methods rtr answers for path, as listed
+// by the Allow header of OPTIONS and 405 Method Not Allowed responses.
+func (rtr *Router) Allowed(path string) []string {
+	return rtr.allowed(path, "")
+}
+
+// DumpTree writes the tree routes are matched by, one per method and one for
+// the routes of all methods, e.g.
+//
+//	GET
+//	├── repos
+//	│   └── :owner
+//	│       └── pulls → /repos/:owner/pulls
+//	└── * → /*
+//
+// Segments are listed in the order they were added. Of several children
+// matching a segment, static ones are tried first, then constrained params,
+// params and a catch-all, as described at Add.
+func (rtr *Router) DumpTree(w io.Writer) error {
+	methods := make([]string, 0, len(rtr.routes))
+	for method := range rtr.routes {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	for i, method := range methods {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+
+		label := method
+		if label == "" {
+			label = "all methods"
+		}
+
+		if _, err := fmt.Fprintln(w, label); err != nil {
+			return err
+		}
+
+		if err := dumpChildren(w, rtr.routes[method], ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func dumpChildren(w io.Writer, n *node, indent string) error {
+	for i, child := range n.children {
+		branch, nested := "├── ", "│   "
+		if i == len(n.children)-1 {
+			branch, nested = "└── ", "    "
+		}
+
+		label := child.path
+		if label == "" {
+			label = `""`
+		}
+
+		if child.handle != nil && child.route != nil {
+			label += " → " + child.route.Path
+		}
+
+		if _, err := fmt.Fprintln(w, indent+branch+label); err != nil {
+			return err
+		}
+
+		if err := dumpChildren(w, child, indent+nested); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
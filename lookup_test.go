@@ -0,0 +1,119 @@
+package router
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shyamz-22/router/fixture"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	noop := func(w http.ResponseWriter, r *http.Request, params PathParams) {}
+
+	pulls := rtr.AddGet("/repos/:owner/:repo/pulls/:number:[0-9]+", noop)
+	latest := rtr.AddGet("/repos/:owner/:repo/pulls/latest", noop)
+	files := rtr.AddGet("/files/*path", noop)
+	rtr.AddPost("/repos/:owner/:repo/pulls/:number:[0-9]+", noop)
+
+	for _, tc := range []struct {
+		method, path string
+		route        *Route
+		params       PathParams
+	}{
+		{http.MethodGet, "/repos/a/b/pulls/1", pulls, PathParams{{"owner", "a"}, {"repo", "b"}, {"number", "1"}}},
+		{http.MethodGet, "/repos/a/b/pulls/latest", latest, PathParams{{"owner", "a"}, {"repo", "b"}}},
+		{http.MethodHead, "/files/a/b", files, PathParams{{"path", "a/b"}}},
+		{http.MethodGet, "/repos/a/b/pulls/first", nil, nil},
+		{http.MethodDelete, "/repos/a/b/pulls/1", nil, nil},
+		{http.MethodGet, "*", nil, nil},
+	} {
+		route, params := rtr.Lookup(tc.method, tc.path)
+		if route != tc.route || !reflect.DeepEqual(params, tc.params) {
+			t.Errorf("\n%s %s\nExpected: %v %v\nActual: %v %v", tc.method, tc.path, tc.route, tc.params, route, params)
+		}
+	}
+
+	t.Run("agrees with ServeHTTP", func(t *testing.T) {
+		rtr := New()
+		for _, route := range fixture.Routes {
+			rtr.Add(route.Path, route.Method, noop)
+		}
+
+		for _, route := range fixture.RoutesWithPathValues {
+			found, _ := rtr.Lookup(route.Method, route.Path)
+			handle, _ := rtr.routes[route.Method].findRoute(route.Path)
+
+			if (found == nil) != (handle == nil) {
+				t.Errorf("%s %s: Lookup found %v", route.Method, route.Path, found)
+			}
+		}
+	})
+	t.Run("agrees with ServeHTTP on the params of the root path", func(t *testing.T) {
+		for _, path := range []string{"/*path", "/:id"} {
+			rtr := New()
+			rtr.AddGet(path, noop)
+
+			_, expected := rtr.Lookup(http.MethodGet, "/")
+			_, params := rtr.routes[http.MethodGet].findRoute("/")
+
+			if !reflect.DeepEqual(PathParams(params), expected) {
+				t.Errorf("\n%s\nExpected: %v\nActual: %v", path, expected, params)
+			}
+		}
+	})
+}
+
+func TestAllowed(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	noop := func(w http.ResponseWriter, r *http.Request, params PathParams) {}
+
+	rtr.AddGet("/pings/:id", noop)
+	rtr.AddDelete("/pings/:id", noop)
+
+	if allowed := rtr.Allowed("/pings/1"); !reflect.DeepEqual(allowed, []string{"DELETE", "GET", "HEAD", "OPTIONS"}) {
+		t.Errorf("Unexpected methods %v", allowed)
+	}
+
+	if allowed := rtr.Allowed("/pongs/1"); allowed != nil {
+		t.Errorf("Unexpected methods %v", allowed)
+	}
+}
+
+func TestDumpTree(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	noop := func(w http.ResponseWriter, r *http.Request, params PathParams) {}
+
+	rtr.AddGet("/repos/:owner", noop)
+	rtr.AddGet("/repos/:owner/pulls/:number:[0-9]+", noop)
+	rtr.AddGet("/static/*path", noop)
+	rtr.AddGet("/", noop)
+	rtr.AddPost("/repos/:owner", noop)
+
+	var b strings.Builder
+	if err := rtr.DumpTree(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `GET
+├── repos
+│   └── :owner → /repos/:owner
+│       └── pulls
+│           └── :number:[0-9]+ → /repos/:owner/pulls/:number:[0-9]+
+├── static
+│   └── *path → /static/*path
+└── / → /
+
+POST
+└── repos
+    └── :owner → /repos/:owner
+`
+	if b.String() != expected {
+		t.Errorf("\nExpected:\n%s\nActual:\n%s", expected, b.String())
+	}
+}
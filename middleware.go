@@ -0,0 +1,62 @@
+package router
+
+import (
+	"net/http"
+)
+
+// Middleware wraps the handler of a route. It is called once when the route
+// is registered or the global middleware changes, never per request, and
+// receives the Route the returned handler serves.
+type Middleware func(route *Route, next HandlerFuncWithParam) HandlerFuncWithParam
+
+// Use appends global middleware, which wraps every route outside of its own
+// middleware. Routes already registered are composed again.
+func (rtr *Router) Use(middleware ...Middleware) {
+	rtr.middleware = append(rtr.middleware, middleware...)
+
+	for _, route := range rtr.routeList {
+		rtr.compose(route)
+	}
+}
+
+// compose builds the handler chain of route, global middleware first, then
+// the middleware of its groups, and installs it in the tree.
+func (rtr *Router) compose(route *Route) {
+	handle := route.handler
+
+	for i := len(route.middleware) - 1; i >= 0; i-- {
+		handle = route.middleware[i](route, handle)
+	}
+
+	groupMiddleware := route.group.chain()
+	for i := len(groupMiddleware) - 1; i >= 0; i-- {
+		handle = groupMiddleware[i](route, handle)
+	}
+
+	for i := len(rtr.middleware) - 1; i >= 0; i-- {
+		handle = rtr.middleware[i](route, handle)
+	}
+
+	for _, n := range route.nodes {
+		n.handle = handle
+	}
+}
+
+// StdMiddleware adapts middleware of the func(http.Handler) http.Handler
+// kind. The path params travel through the request context, see
+// ParamsFromContext.
+func StdMiddleware(middleware func(http.Handler) http.Handler) Middleware {
+	return func(route *Route, next HandlerFuncWithParam) HandlerFuncWithParam {
+		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+			next(w, request, ParamsFromContext(request.Context()))
+		}))
+
+		return func(w http.ResponseWriter, request *http.Request, params PathParams) {
+			if len(params) > 0 {
+				request = withParams(request, params)
+			}
+
+			handler.ServeHTTP(w, request)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func trace(name string) Middleware {
+	return func(route *Route, next HandlerFuncWithParam) HandlerFuncWithParam {
+		return func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write([]byte(name + "(" + route.Path + ") "))
+			next(w, r, params)
+		}
+	}
+}
+
+func TestRouteWithMiddleware(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.Use(trace("global"))
+
+	rtr.AddGet("/pings/:id", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte(params.ByName("id")))
+	}, trace("first"), trace("second"))
+
+	rtr.AddGet("/pongs", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pong)
+	})
+
+	rtr.Use(trace("late"))
+
+	t.Run("wraps global outside of route middleware", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings/1", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "global(/pings/:id) late(/pings/:id) first(/pings/:id) second(/pings/:id) 1")
+	})
+
+	t.Run("wraps routes without middleware", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pongs", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "global(/pongs) late(/pongs) Pong!")
+	})
+}
+
+func TestRouteWithMiddlewareComposedOnce(t *testing.T) {
+	t.Parallel()
+	composed := 0
+	rtr := New()
+	rtr.AddGet("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pong)
+	}, func(route *Route, next HandlerFuncWithParam) HandlerFuncWithParam {
+		composed++
+		return next
+	})
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "Pong!")
+	}
+
+	if composed != 1 {
+		t.Fatalf("\nExpected: 1\nActual:%d\n", composed)
+	}
+}
+
+func TestRouteWithStdMiddleware(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.Use(StdMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Ping", ParamsFromContext(r.Context()).ByName("id"))
+			next.ServeHTTP(w, r)
+		})
+	}))
+
+	rtr.AddGet("/pings/:id", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte(params.ByName("id")))
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/pings/1", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	assert.ResponseWithBody(t, w, http.StatusOK, "1")
+	assert.ResponseWithHeader(t, w, "X-Ping", "1")
+}
+
+func TestRouteWithRegisteredRoutes(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	noop := func(w http.ResponseWriter, r *http.Request, params PathParams) {}
+
+	rtr.AddGet("/pings", noop)
+	rtr.Add("GET /pings/{id}", "", noop)
+	rtr.AddPost("/pings", noop)
+	rtr.AddGet("/pings", noop)
+	rtr.Add("/hooks/:name", "", noop)
+
+	var actual []string
+	for _, route := range rtr.Routes() {
+		actual = append(actual, route.Method+" "+route.Path)
+	}
+
+	expected := []string{"GET /pings/:id", "POST /pings", "GET /pings", " /hooks/:name"}
+	if len(actual) != len(expected) {
+		t.Fatalf("\nExpected: %q\nActual:%q\n", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("\nExpected: %q\nActual:%q\n", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type mountKey struct{}
+
+// mountPoint describes where a request entered a mounted handler.
+type mountPoint struct {
+	router *Router
+	path   string
+	outer  *mountPoint
+}
+
+// Mount delegates the requests of all methods for prefix and every path below
+// it to handler, OPTIONS requests and methods the router does not know
+// included, after the routes of the request method. The handler sees the
+// request path with the prefix stripped. The path params of the prefix are
+// available through ParamsFromContext and http.Request.PathValue. A mounted
+// Router passes them to its routes in front of their own params, and falls
+// back to the NotFound and MethodNotAllowed handlers of rtr, which receive
+// the full path.
+func (rtr *Router) Mount(prefix string, handler http.Handler, middleware ...Middleware) *Route {
+	route := rtr.mount(prefix, handler, middleware)
+	rtr.compose(route)
+
+	return route
+}
+
+// mount registers the route of a mount point without composing its handler
+// chain. The route has the path prefix + "/*" and also serves prefix itself.
+func (rtr *Router) mount(prefix string, handler http.Handler, middleware []Middleware) *Route {
+	method, prefix := parsePattern(strings.TrimSuffix(prefix, sep))
+
+	if method != "" {
+		panic(fmt.Sprintf("Invalid Mount: %s. Mount points serve all methods\n", prefix))
+	}
+
+	for _, segment := range strings.Split(prefix, sep) {
+		if isCatchAll(segment) {
+			panic(fmt.Sprintf("Invalid Mount: %s. Mount points must not have a catch-all\n", prefix))
+		}
+	}
+
+	if sub, ok := handler.(*Router); ok {
+		sub.mounted = true
+	}
+
+	route := &Route{
+		Path:       prefix + sep + string(catchAllSepChar),
+		handler:    mountHandler(rtr, prefix, handler),
+		middleware: middleware,
+		mount:      true,
+	}
+	route.indexParams()
+
+	root := prefix
+	if root == "" {
+		root = sep
+	}
+
+	// all methods, including those the router does not know
+	rtr.insertPath(route, "", root)
+	rtr.insertPath(route, "", route.Path)
+
+	rtr.routeList = append(rtr.routeList, route)
+
+	return route
+}
+
+func mountHandler(rtr *Router, prefix string, handler http.Handler) HandlerFuncWithParam {
+	segments := strings.Count(prefix, sep)
+
+	return func(w http.ResponseWriter, request *http.Request, params PathParams) {
+		outer, _ := request.Context().Value(mountKey{}).(*mountPoint)
+
+		ctx := context.WithValue(request.Context(), mountKey{}, &mountPoint{
+			router: rtr,
+			path:   request.URL.Path,
+			outer:  outer,
+		})
+
+		if len(params) > 0 {
+			ctx = &paramsContext{Context: ctx, params: params}
+		}
+
+		stripped := request.WithContext(ctx)
+		u := *request.URL
+		u.Path = stripSegments(u.Path, segments)
+		if u.RawPath != "" {
+			u.RawPath = stripSegments(u.RawPath, segments)
+		}
+		stripped.URL = &u
+
+		for _, p := range params {
+			stripped.SetPathValue(p.Key, p.Value)
+		}
+
+		handler.ServeHTTP(w, stripped)
+	}
+}
+
+// stripSegments removes the first n segments of path. The root is left of a
+// path that has no more segments.
+func stripSegments(path string, n int) string {
+	for ; n > 0 && len(path) > 0; n-- {
+		_, path = nextSegment(path[1:])
+	}
+
+	if path == "" {
+		return sep
+	}
+
+	return path
+}
+
+// mountedParams puts the params of the mount point of a mounted router in
+// front of the params of its route.
+func mountedParams(request *http.Request, params PathParams) PathParams {
+	outer := ParamsFromContext(request.Context())
+	if len(outer) == 0 {
+		return params
+	}
+
+	merged := make(PathParams, 0, len(outer)+len(params))
+	merged = append(merged, outer...)
+
+	return append(merged, params...)
+}
+
+// mountedErrorHandler picks the error handler of the routers a router is
+// mounted on, innermost first, along with the route error for their path.
+func mountedErrorHandler(request *http.Request, e *RouteError) (http.Handler, *RouteError) {
+	m, _ := request.Context().Value(mountKey{}).(*mountPoint)
+
+	for ; m != nil; m = m.outer {
+		outer := *e
+		outer.Path = m.path
+
+		if handler := m.router.errorHandler(&outer); handler != nil {
+			return handler, &outer
+		}
+	}
+
+	return nil, e
+}
@@ -0,0 +1,151 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouteWithMountedRouter(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e, _ := RouteErrorFromContext(r.Context())
+		w.WriteHeader(e.Status)
+		w.Write([]byte("not found: " + e.Path))
+	})
+
+	admin := New()
+	admin.AddGet("/", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte("dashboard of " + params.ByName("tenant")))
+	})
+
+	admin.AddDelete("/users/:id", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte(params.ByName("tenant") + " deleted " + params.ByName("id")))
+	})
+
+	admin.HandleFunc(http.MethodGet, "/path", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path + " of " + r.PathValue("tenant")))
+	})
+
+	rtr.Mount("/tenants/:tenant/admin", admin)
+
+	t.Run("serves the prefix itself", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/tenants/acme/admin", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "dashboard of acme")
+	})
+
+	t.Run("hands over the params of the prefix", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodDelete, "/tenants/acme/admin/users/42", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "acme deleted 42")
+	})
+
+	t.Run("strips the prefix", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/tenants/acme/admin/path", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "/path of acme")
+	})
+
+	t.Run("falls back to not found handler of the parent", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/tenants/acme/admin/unknown", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, "not found: /tenants/acme/admin/unknown")
+	})
+
+	t.Run("answers method not allowed of the mounted router", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/tenants/acme/admin/users/42", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusMethodNotAllowed)
+		assert.ResponseWithHeader(t, w, "Allow", "DELETE, OPTIONS")
+	})
+
+	t.Run("forwards OPTIONS requests", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodOptions, "/tenants/acme/admin/users/42", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNoContent)
+		assert.ResponseWithHeader(t, w, "Allow", "DELETE, OPTIONS")
+	})
+
+	t.Run("lists the mount point as route", func(t *testing.T) {
+		routes := rtr.Routes()
+
+		if len(routes) != 1 || routes[0].Path != "/tenants/:tenant/admin/*" || routes[0].Method != "" {
+			t.Errorf("Expected mount point route but got %v", routes)
+		}
+	})
+}
+
+func TestRouteWithMountedHandler(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddGet("/metrics/health", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte("ok"))
+	})
+
+	api := rtr.Group("/api", trace("api"))
+	api.Mount("/metrics", http.StripPrefix("/prometheus", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method + " " + r.URL.Path))
+	})))
+
+	rtr.Mount("/metrics/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("metrics " + r.URL.Path))
+	}))
+
+	t.Run("delegates methods the router does not know", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PROPFIND", "/metrics/dav", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "metrics /dav")
+	})
+
+	t.Run("delegates all methods", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodPost, "/api/metrics/prometheus/push", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "api(/api/metrics/*) POST /push")
+	})
+
+	t.Run("prefers routes of the router", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/metrics/health", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "ok")
+	})
+
+	t.Run("passes the root for the prefix itself", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "metrics /")
+	})
+}
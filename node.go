@@ -2,22 +2,28 @@ package router
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
 const (
 	sep              = "/"
 	pathParamSepChar = ':'
+	catchAllSepChar  = '*'
 	sepChar          = '/'
 )
 
 type node struct {
-	path     string
-	handle   HandlerFuncWithParam
-	children []*node
+	path       string
+	key        string
+	constraint *regexp.Regexp
+	handle     HandlerFuncWithParam
+	children   []*node
+	catchAll   *node
+	route      *Route
 }
 
-func (n *node) addRoute(path string, handler HandlerFuncWithParam) {
+func (n *node) addRoute(path string, handler HandlerFuncWithParam) *node {
 	var parts []string
 	var child *node
 
@@ -33,10 +39,16 @@ func (n *node) addRoute(path string, handler HandlerFuncWithParam) {
 	}
 
 	for i := range parts {
+		if isCatchAll(parts[i]) && i < len(parts)-1 {
+			panic(fmt.Sprintf("Invalid Path: %s. Catch-all must be the last segment\n", path))
+		}
+
 		child = addPath(n, child, parts[i], i)
 	}
 
 	child.handle = handler
+
+	return child
 }
 
 // Index vars
@@ -51,9 +63,23 @@ func (n *node) findRoute(path string) (HandlerFuncWithParam, []Param) {
 		paramsSize int
 	)
 
+	// only absolute paths can match, e.g. not the asterisk-form "*"
+	if len(path) == 0 || path[0] != sepChar {
+		return nil, nil
+	}
+
+	fullPath := path
+
 	// handle Index
 	if isIndex(path) {
 		child, p = findPath(n, child, path, true)
+
+		// a wildcard matches the empty segment after the slash
+		if len(p.Key) > 0 {
+			p.Value = p.Value[:0]
+			params = []Param{p}
+			paramsSize = 1
+		}
 	} else {
 		// Prepare for path param parsing
 		isRoot := true
@@ -63,6 +89,7 @@ func (n *node) findRoute(path string) (HandlerFuncWithParam, []Param) {
 
 		for nextSepIndex >= 0 {
 			var part string
+			remaining := path
 
 			// next occurrence of /. This reduces 6900 ns/op
 			index := -1
@@ -83,6 +110,12 @@ func (n *node) findRoute(path string) (HandlerFuncWithParam, []Param) {
 
 			child, p = findPath(n, child, part, isRoot)
 
+			// catch-all takes the remaining path, slashes included
+			if child != nil && isCatchAll(child.path) && nextSepIndex >= 0 {
+				p.Value = remaining
+				nextSepIndex = -1
+			}
+
 			// collect path params
 			if len(p.Key) > 0 {
 
@@ -101,11 +134,105 @@ func (n *node) findRoute(path string) (HandlerFuncWithParam, []Param) {
 		}
 	}
 
+	// the first match of each segment did not lead to a handle, others may
+	if child == nil || child.handle == nil {
+		return n.matchRoute(fullPath)
+	}
+
+	return child.handle, params[:paramsSize]
+}
+
+// matchRoute is the slow path of findRoute. It tries all children matching
+// a segment, in the order of findChild, and backtracks whenever a child does
+// not lead to a handle.
+func (n *node) matchRoute(path string) (HandlerFuncWithParam, []Param) {
+	child, params := n.lookup(path)
 	if child == nil {
 		return nil, nil
 	}
 
-	return child.handle, params[:paramsSize]
+	return child.handle, params
+}
+
+// lookup returns the node with a handle that matches path, as matchRoute.
+func (n *node) lookup(path string) (*node, []Param) {
+	if len(path) == 0 || path[0] != sepChar {
+		return nil, nil
+	}
+
+	if isIndex(path) {
+		if child, _ := findChild(n, path); child != nil && child.path == path && child.handle != nil {
+			return child, nil
+		}
+	}
+
+	params := make([]Param, 0, strings.Count(path, sep))
+
+	child, params := matchChildren(n, path[1:], params)
+	if child == nil {
+		return nil, nil
+	}
+
+	return child, params
+}
+
+func matchChildren(n *node, path string, params []Param) (*node, []Param) {
+	part, rest := path, ""
+	last := true
+
+	if i := strings.IndexByte(path, sepChar); i >= 0 {
+		part, rest, last = path[:i], path[i+1:], false
+	}
+
+	// actual match
+	for _, child := range n.children {
+		if child.path == part && !isParam(part) && !isCatchAll(part) {
+			if match, matchParams := matchNext(child, rest, last, params); match != nil {
+				return match, matchParams
+			}
+			break
+		}
+	}
+
+	// path params, constrained first
+	for _, constrained := range []bool{true, false} {
+		for _, child := range n.children {
+			if !isParam(child.path) || (child.constraint != nil) != constrained {
+				continue
+			}
+
+			if constrained && !child.constraint.MatchString(part) {
+				continue
+			}
+
+			if match, matchParams := matchNext(child, rest, last, append(params, Param{Key: child.key, Value: part})); match != nil {
+				return match, matchParams
+			}
+		}
+	}
+
+	// catch-all takes the remaining path, slashes included
+	if child := n.catchAll; child != nil && child.handle != nil {
+		if len(child.key) > 0 {
+			params = append(params, Param{Key: child.key, Value: path})
+		}
+
+		return child, params
+	}
+
+	return nil, params
+}
+
+func matchNext(child *node, rest string, last bool, params []Param) (*node, []Param) {
+	if last {
+		if child.handle != nil {
+			return child, params
+		}
+
+		return nil, params
+	}
+
+	return matchChildren(child, rest, params)
 }
 
 func findPath(root, child *node, part string, isRoot bool) (*node, Param) {
@@ -133,40 +260,104 @@ func addPath(n, child *node, part string, i int) *node {
 }
 
 func (n *node) insertChild(part string) (*node) {
-	if existingChild, _ := findChild(n, part); existingChild != nil {
-		return existingChild
+	key, constraint := splitParam(part)
+
+	for _, existingChild := range n.children {
+		if existingChild.path == part {
+			return existingChild
+		}
 	}
 
 	child := &node{
 		path: part,
+		key:  key,
+	}
+
+	if constraint != "" {
+		re, err := regexp.Compile("^(?:" + constraint + ")$")
+		if err != nil {
+			panic(fmt.Sprintf("Invalid Path Param: %s. %v\n", part, err))
+		}
+		child.constraint = re
 	}
 
 	n.children = append(n.children, child)
 
+	if isCatchAll(part) {
+		n.catchAll = child
+	}
+
 	return child
 }
 
+// findChild matches path against the children of n. An actual match wins
+// over a path param with a matching constraint, which wins over a path param
+// without constraint, which wins over a catch-all.
 func findChild(n *node, path string) (*node, Param) {
-	p := Param{}
+	var constrained, param, catchAll *node
 
 	for _, child := range n.children {
 		// actual match
 		if child.path == path {
-			return child, p
+			return child, Param{}
 		}
 
-		// path param match
-		if len(child.path) > 0 && child.path[0] == pathParamSepChar {
-			p.Key = child.path[1:]
-			p.Value = path
+		if isParam(child.path) {
+			if child.constraint == nil {
+				if param == nil {
+					param = child
+				}
+			} else if constrained == nil && child.constraint.MatchString(path) {
+				constrained = child
+			}
+		}
 
-			return child, p
+		if catchAll == nil && isCatchAll(child.path) {
+			catchAll = child
 		}
 	}
 
-	return nil, p
+	// path param match
+	if constrained != nil {
+		return constrained, Param{Key: constrained.key, Value: path}
+	}
+
+	if param != nil {
+		return param, Param{Key: param.key, Value: path}
+	}
+
+	// catch-all match
+	if catchAll != nil {
+		return catchAll, Param{Key: catchAll.key, Value: path}
+	}
+
+	return nil, Param{}
 }
 
 func isIndex(path string) bool {
 	return sep == path
 }
+
+// splitParam returns the name and the regular expression of a path param or
+// catch-all such as ":id:[0-9]+". Static parts have neither.
+func splitParam(part string) (key, constraint string) {
+	if !isParam(part) && !isCatchAll(part) {
+		return "", ""
+	}
+
+	key = part[1:]
+
+	if i := strings.IndexByte(key, pathParamSepChar); i >= 0 && isParam(part) {
+		return key[:i], key[i+1:]
+	}
+
+	return key, ""
+}
+
+func isParam(part string) bool {
+	return len(part) > 0 && part[0] == pathParamSepChar
+}
+
+func isCatchAll(part string) bool {
+	return len(part) > 0 && part[0] == catchAllSepChar
+}
@@ -0,0 +1,246 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/shyamz-22/router"
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads an OpenAPI document in JSON or YAML. Parts of the document
+// Document does not describe are ignored.
+func Load(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		err = json.Unmarshal(trimmed, doc)
+	} else {
+		err = yaml.Unmarshal(data, doc)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// LoadFile reads the OpenAPI document in JSON or YAML of the named file.
+func LoadFile(name string) (*Document, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	doc, err := Load(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return doc, nil
+}
+
+// Handlers maps the operation IDs of a document to their handlers.
+type Handlers map[string]router.HandlerFuncWithParam
+
+// Bind registers the handler of every operation of doc with rtr, wrapped by
+// the given middleware:
+//
+//	doc, err := openapi.LoadFile("api.yaml")
+//	...
+//	err = openapi.Bind(rtr, doc, openapi.Handlers{
+//		"getRepo":    getRepo,
+//		"createRepo": createRepo,
+//	})
+//
+// Paths are translated by RoutePath and checked by rtr.Check. The operation
+// ID, summary, description, tags and deprecation of operations are set as
+// Meta of their routes.
+//
+// Nothing is registered if an operation has no handler, a handler has no
+// operation or an operation cannot be registered. The returned *BindError
+// lists all of them.
+func Bind(rtr router.Registrar, doc *Document, handlers Handlers, middleware ...router.Middleware) error {
+	type binding struct {
+		method, path string
+		op           *Operation
+		handler      router.HandlerFuncWithParam
+	}
+
+	var (
+		bindings []binding
+		report   BindError
+		seen     = make(map[string]bool)
+	)
+
+	for _, path := range sortedPaths(doc) {
+		item := doc.Paths[path]
+		if item == nil {
+			continue
+		}
+
+		for _, method := range sortedMethods(item) {
+			op := item.Operations()[method]
+			where := method + " " + path
+
+			if op.OperationID == "" {
+				report.Invalid = append(report.Invalid, where+": no operationId")
+				continue
+			}
+
+			if seen[op.OperationID] {
+				report.Invalid = append(report.Invalid, where+": duplicate operationId "+op.OperationID)
+				continue
+			}
+			seen[op.OperationID] = true
+
+			routePath, err := RoutePath(path, append(item.Parameters, op.Parameters...))
+			if err == nil {
+				err = rtr.Check(routePath, method)
+			}
+
+			if err != nil {
+				report.Invalid = append(report.Invalid, where+": "+err.Error())
+				continue
+			}
+
+			handler, ok := handlers[op.OperationID]
+			if !ok {
+				report.MissingHandlers = append(report.MissingHandlers, where+" ("+op.OperationID+")")
+				continue
+			}
+
+			bindings = append(bindings, binding{method, routePath, op, handler})
+		}
+	}
+
+	for id := range handlers {
+		// handlers of invalid operations are reported with them
+		if !seen[id] {
+			report.MissingOperations = append(report.MissingOperations, id)
+		}
+	}
+	sort.Strings(report.MissingOperations)
+
+	if len(report.MissingHandlers) > 0 || len(report.MissingOperations) > 0 || len(report.Invalid) > 0 {
+		return &report
+	}
+
+	for _, b := range bindings {
+		route := rtr.Add(b.path, b.method, b.handler, middleware...).Set(OperationID, b.op.OperationID)
+
+		if b.op.Summary != "" {
+			route.Set(Summary, b.op.Summary)
+		}
+		if b.op.Description != "" {
+			route.Set(Description, b.op.Description)
+		}
+		if len(b.op.Tags) > 0 {
+			route.Set(Tags, b.op.Tags)
+		}
+		if b.op.Deprecated {
+			route.Set(Deprecated, true)
+		}
+	}
+
+	return nil
+}
+
+// BindError reports why Bind registered no route.
+type BindError struct {
+	// MissingHandlers lists the operations without handler, e.g.
+	// "GET /repos/{owner} (getRepos)".
+	MissingHandlers []string
+	// MissingOperations lists the IDs of handlers without operation.
+	MissingOperations []string
+	// Invalid lists the operations that cannot be registered and why.
+	Invalid []string
+}
+
+func (e *BindError) Error() string {
+	var b strings.Builder
+	b.WriteString("openapi: cannot bind handlers")
+
+	list := func(title string, entries []string) {
+		if len(entries) == 0 {
+			return
+		}
+
+		fmt.Fprintf(&b, "\n%s:", title)
+		for _, entry := range entries {
+			b.WriteString("\n\t" + entry)
+		}
+	}
+
+	list("operations without handler", e.MissingHandlers)
+	list("handlers without operation", e.MissingOperations)
+	list("invalid operations", e.Invalid)
+
+	return b.String()
+}
+
+// RoutePath translates an OpenAPI path into a route path, e.g.
+// "/repos/{owner}" into "/repos/:owner". Path params with an integer schema
+// among params only match digits. Params must span whole segments.
+func RoutePath(path string, params []*Parameter) (string, error) {
+	segments := strings.Split(path, "/")
+
+	for i, segment := range segments {
+		if !strings.ContainsAny(segment, "{}") {
+			continue
+		}
+
+		if len(segment) < 3 || segment[0] != '{' || segment[len(segment)-1] != '}' {
+			return "", fmt.Errorf("param in %q does not span the segment", segment)
+		}
+
+		name := segment[1 : len(segment)-1]
+		segments[i] = ":" + name
+
+		for _, param := range params {
+			if param.In == "path" && param.Name == name && param.Schema != nil && param.Schema.Type == "integer" {
+				segments[i] += ":[0-9]+"
+			}
+		}
+	}
+
+	return strings.Join(segments, "/"), nil
+}
+
+func sortedPaths(doc *Document) []string {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+func sortedMethods(item *PathItem) []string {
+	var methods []string
+
+	for _, method := range []string{
+		http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+		http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace,
+	} {
+		if _, ok := item.Operations()[method]; ok {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods
+}
@@ -0,0 +1,205 @@
+package openapi
+
+import (
+	"github.com/shyamz-22/router"
+	"github.com/shyamz-22/router/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const reposSpec = `
+openapi: 3.1.0
+info:
+  title: Repos
+  version: 1.0.0
+paths:
+  /repos/{owner}:
+    parameters:
+      - name: owner
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: listRepos
+      summary: List repositories
+      tags: [repos]
+    post:
+      operationId: createRepo
+  /repos/{owner}/pulls/{number}:
+    get:
+      operationId: getPull
+      parameters:
+        - name: number
+          in: path
+          required: true
+          schema:
+            type: integer
+`
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads YAML", func(t *testing.T) {
+		doc, err := Load(strings.NewReader(reposSpec))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if op := doc.Paths["/repos/{owner}"].Get; op == nil || op.OperationID != "listRepos" || op.Tags[0] != "repos" {
+			t.Errorf("Unexpected operation %+v", op)
+		}
+	})
+
+	t.Run("reads JSON", func(t *testing.T) {
+		doc, err := Load(strings.NewReader(`{"openapi": "3.1.0", "paths": {"/pings": {"get": {"operationId": "ping"}}}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if op := doc.Paths["/pings"].Get; op == nil || op.OperationID != "ping" {
+			t.Errorf("Unexpected operation %+v", op)
+		}
+	})
+
+	t.Run("rejects invalid documents", func(t *testing.T) {
+		if _, err := Load(strings.NewReader(`{"paths": [}`)); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Load(strings.NewReader(reposSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	respond := func(body string) router.HandlerFuncWithParam {
+		return func(w http.ResponseWriter, r *http.Request, params router.PathParams) {
+			var values []string
+			for _, p := range params {
+				values = append(values, p.Value)
+			}
+			w.Write([]byte(body + " " + strings.Join(values, ",")))
+		}
+	}
+
+	t.Run("registers handlers by operationId", func(t *testing.T) {
+		rtr := router.New()
+
+		err := Bind(rtr, doc, Handlers{
+			"listRepos":  respond("list"),
+			"createRepo": respond("create"),
+			"getPull":    respond("pull"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, tc := range []struct{ method, path, body string }{
+			{http.MethodGet, "/repos/golang", "list golang"},
+			{http.MethodPost, "/repos/golang", "create golang"},
+			{http.MethodGet, "/repos/golang/pulls/42", "pull golang,42"},
+		} {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(tc.method, tc.path, nil)
+
+			rtr.ServeHTTP(w, r)
+
+			assert.ResponseWithBody(t, w, http.StatusOK, tc.body)
+		}
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/repos/golang/pulls/latest", nil)
+		rtr.ServeHTTP(w, r)
+		assert.ResponseWithStatus(t, w, http.StatusNotFound)
+	})
+
+	t.Run("sets the meta of routes", func(t *testing.T) {
+		rtr := router.New()
+
+		Bind(rtr, doc, Handlers{"listRepos": respond(""), "createRepo": respond(""), "getPull": respond("")})
+
+		route := rtr.Routes()[0]
+		if route.Method != http.MethodGet || route.Path != "/repos/:owner" ||
+			route.Meta[OperationID] != "listRepos" || route.Meta[Summary] != "List repositories" {
+			t.Errorf("Unexpected route %s %s %v", route.Method, route.Path, route.Meta)
+		}
+	})
+
+	t.Run("reports missing handlers and operations", func(t *testing.T) {
+		rtr := router.New()
+
+		err := Bind(rtr, doc, Handlers{"listRepos": respond(""), "deleteRepo": respond("")})
+
+		expected := `openapi: cannot bind handlers
+operations without handler:
+	POST /repos/{owner} (createRepo)
+	GET /repos/{owner}/pulls/{number} (getPull)
+handlers without operation:
+	deleteRepo`
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected: %s\nActual:   %v", expected, err)
+		}
+
+		if len(rtr.Routes()) != 0 {
+			t.Errorf("Expected no routes but got %d", len(rtr.Routes()))
+		}
+	})
+
+	t.Run("reports invalid operations", func(t *testing.T) {
+		doc, _ := Load(strings.NewReader(`{"paths": {
+			"/files/{name}.json": {"get": {"operationId": "getFile"}},
+			"/pings": {"get": {}, "post": {"operationId": "getFile"}}
+		}}`))
+
+		err := Bind(router.New(), doc, Handlers{"getFile": respond("")})
+
+		bindErr, ok := err.(*BindError)
+		if !ok || len(bindErr.Invalid) != 3 || len(bindErr.MissingOperations) != 0 {
+			t.Errorf("Unexpected error %v", err)
+		}
+	})
+
+	t.Run("registers nothing if a path cannot be registered", func(t *testing.T) {
+		doc, _ := Load(strings.NewReader(`{"paths": {
+			"/a": {"get": {"operationId": "getA"}},
+			"b/{id}": {"get": {"operationId": "getB"}}
+		}}`))
+
+		rtr := router.New()
+		err := Bind(rtr, doc, Handlers{"getA": respond(""), "getB": respond("")})
+
+		bindErr, ok := err.(*BindError)
+		if !ok || len(bindErr.Invalid) != 1 || !strings.HasPrefix(bindErr.Invalid[0], "GET b/{id}: ") {
+			t.Errorf("Unexpected error %v", err)
+		}
+
+		if len(rtr.Routes()) != 0 {
+			t.Errorf("Expected no routes but got %d", len(rtr.Routes()))
+		}
+	})
+}
+
+func TestRoutePath(t *testing.T) {
+	t.Parallel()
+
+	params := []*Parameter{{Name: "id", In: "path", Schema: &Schema{Type: "integer"}}}
+
+	for path, expected := range map[string]string{
+		"/pings":               "/pings",
+		"/repos/{owner}":       "/repos/:owner",
+		"/items/{id}/versions": "/items/:id:[0-9]+/versions",
+	} {
+		if actual, err := RoutePath(path, params); err != nil || actual != expected {
+			t.Errorf("\nPath: %s\nExpected: %s\nActual: %s %v", path, expected, actual, err)
+		}
+	}
+}
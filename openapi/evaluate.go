@@ -0,0 +1,322 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shyamz-22/router"
+)
+
+// violation is a value not matching its schema, at a JSON pointer into the
+// validated document.
+type violation struct {
+	pointer string
+	detail  string
+}
+
+// evaluator validates decoded JSON values against the schemas of a document.
+// It supports $ref to components, type, enum, the bounds of numbers,
+// strings, arrays and objects, pattern, the formats date-time, date and uuid,
+// items, properties, required, additionalProperties, allOf, anyOf, oneOf
+// and not.
+type evaluator struct {
+	doc *Document
+}
+
+var patterns sync.Map // string → *regexp.Regexp, or error
+
+func (e *evaluator) validate(s *Schema, v interface{}, pointer string) []violation {
+	var violations []violation
+	e.check(s, v, pointer, &violations)
+
+	// properties are checked in map order
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].pointer < violations[j].pointer
+	})
+
+	return violations
+}
+
+func (e *evaluator) check(s *Schema, v interface{}, pointer string, out *[]violation) {
+	if s == nil {
+		return
+	}
+
+	report := func(format string, args ...interface{}) {
+		*out = append(*out, violation{pointer: pointer, detail: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Ref != "" {
+		target, err := e.resolve(s.Ref)
+		if err != nil {
+			report("%v", err)
+			return
+		}
+		e.check(target, v, pointer, out)
+	}
+
+	for _, sub := range s.AllOf {
+		e.check(sub, v, pointer, out)
+	}
+
+	if len(s.AnyOf) > 0 && e.matching(s.AnyOf, v) == 0 {
+		report("must match a schema of anyOf")
+	}
+
+	if len(s.OneOf) > 0 && e.matching(s.OneOf, v) != 1 {
+		report("must match exactly one schema of oneOf")
+	}
+
+	if s.Not != nil && len(e.validate(s.Not, v, pointer)) == 0 {
+		report("must not match the schema of not")
+	}
+
+	if types := schemaTypes(s); len(types) > 0 && !hasType(types, v) {
+		report("must be of type %s", strings.Join(types, " or "))
+		return
+	}
+
+	if len(s.Enum) > 0 && !inEnum(s.Enum, v) {
+		report("must be one of %v", s.Enum)
+	}
+
+	switch value := v.(type) {
+	case string:
+		checkString(s, value, report)
+
+	case float64:
+		checkNumber(s, value, report)
+
+	case []interface{}:
+		if s.MinItems != nil && len(value) < *s.MinItems {
+			report("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(value) > *s.MaxItems {
+			report("must have at most %d items", *s.MaxItems)
+		}
+
+		for i, item := range value {
+			e.check(s.Items, item, fmt.Sprintf("%s/%d", pointer, i), out)
+		}
+
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := value[name]; !ok {
+				*out = append(*out, violation{pointer: pointer + "/" + escapePointer(name), detail: "is required"})
+			}
+		}
+
+		for name, property := range value {
+			sub, ok := s.Properties[name]
+			if !ok {
+				sub = s.AdditionalProperties
+			}
+
+			e.check(sub, property, pointer+"/"+escapePointer(name), out)
+		}
+	}
+}
+
+func checkString(s *Schema, value string, report func(string, ...interface{})) {
+	length := utf8.RuneCountInString(value)
+
+	if s.MinLength != nil && length < *s.MinLength {
+		report("must be at least %d characters long", *s.MinLength)
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		report("must be at most %d characters long", *s.MaxLength)
+	}
+
+	if s.Pattern != "" {
+		re, err := compilePattern(s.Pattern)
+		switch {
+		case err != nil:
+			report("invalid pattern %q: %v", s.Pattern, err)
+		case !re.MatchString(value):
+			report("must match pattern %q", s.Pattern)
+		}
+	}
+
+	var err error
+	switch s.Format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+	case "date":
+		_, err = time.Parse(time.DateOnly, value)
+	case "uuid":
+		_, err = router.ParseUUID(value)
+	}
+
+	if err != nil {
+		report("must be a valid %s", s.Format)
+	}
+}
+
+func checkNumber(s *Schema, value float64, report func(string, ...interface{})) {
+	if s.Minimum != nil && value < *s.Minimum {
+		report("must be at least %v", *s.Minimum)
+	}
+	if s.Maximum != nil && value > *s.Maximum {
+		report("must be at most %v", *s.Maximum)
+	}
+	if s.ExclusiveMinimum != nil && value <= *s.ExclusiveMinimum {
+		report("must be greater than %v", *s.ExclusiveMinimum)
+	}
+	if s.ExclusiveMaximum != nil && value >= *s.ExclusiveMaximum {
+		report("must be less than %v", *s.ExclusiveMaximum)
+	}
+}
+
+// matching counts the schemas v is valid against.
+func (e *evaluator) matching(schemas []*Schema, v interface{}) int {
+	n := 0
+
+	for _, s := range schemas {
+		if len(e.validate(s, v, "")) == 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (e *evaluator) resolve(ref string) (*Schema, error) {
+	const prefix = "#/components/schemas/"
+
+	if strings.HasPrefix(ref, prefix) && e.doc.Components != nil {
+		if s, ok := e.doc.Components.Schemas[strings.TrimPrefix(ref, prefix)]; ok && s != nil {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot resolve $ref %q", ref)
+}
+
+// deref follows the $ref of s, if any, to the schema it refers to.
+func (e *evaluator) deref(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		target, err := e.resolve(s.Ref)
+		if err != nil {
+			return s
+		}
+		s = target
+	}
+
+	return s
+}
+
+// schemaTypes returns the type of s as list, read from Go or decoded JSON.
+func schemaTypes(s *Schema) []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, typ := range t {
+			if name, ok := typ.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	default:
+		return nil
+	}
+}
+
+func hasType(types []string, v interface{}) bool {
+	for _, typ := range types {
+		switch value := v.(type) {
+		case nil:
+			if typ == "null" {
+				return true
+			}
+		case bool:
+			if typ == "boolean" {
+				return true
+			}
+		case string:
+			if typ == "string" {
+				return true
+			}
+		case float64:
+			if typ == "number" || typ == "integer" && value == math.Trunc(value) {
+				return true
+			}
+		case []interface{}:
+			if typ == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if typ == "object" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func inEnum(enum []interface{}, v interface{}) bool {
+	for _, option := range enum {
+		if n, ok := toFloat(option); ok {
+			if value, ok := v.(float64); ok && value == n {
+				return true
+			}
+			continue
+		}
+
+		if reflect.DeepEqual(option, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toFloat converts numbers decoded from JSON or YAML documents.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := patterns.Load(pattern); ok {
+		if err, ok := cached.(error); ok {
+			return nil, err
+		}
+		return cached.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		patterns.Store(pattern, err)
+		return nil, err
+	}
+
+	patterns.Store(pattern, re)
+
+	return re, nil
+}
+
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
@@ -0,0 +1,314 @@
+// Package openapi describes the routes of a router.Router as OpenAPI 3.1
+// document. Operations are filled from the Meta of the routes:
+//
+//	rtr.AddPost("/repos/:owner", createRepo).
+//		Set(openapi.Summary, "Create a repository").
+//		Set(openapi.Tags, []string{"repos"}).
+//		Set(openapi.RequestType, CreateRepo{}).
+//		Set(openapi.ResponseType, Repo{}).
+//		Set(openapi.Status, http.StatusCreated)
+//
+// Path params become path parameters, typed by the `path` fields of the
+// request type or by their constraint. Fields tagged `query` become query
+// parameters, the JSON fields the request body.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/shyamz-22/router"
+	"gopkg.in/yaml.v3"
+)
+
+// Meta keys of routes read by Generate.
+const (
+	// Summary is the short summary of the operation, a string.
+	Summary = "summary"
+	// Description is the description of the operation, a string.
+	Description = "description"
+	// Tags group operations, a []string.
+	Tags = "tags"
+	// OperationID identifies the operation, a string. It is derived from
+	// method and path if unset, e.g. "getReposByOwnerPulls".
+	OperationID = "operationId"
+	// Deprecated marks the operation as deprecated, a bool.
+	Deprecated = "deprecated"
+	// RequestType is a value of the request type, e.g. CreateRepo{}.
+	RequestType = "request"
+	// ResponseType is a value of the JSON response type, e.g. Repo{}.
+	ResponseType = "response"
+	// Status is the status of successful responses, 200 if unset.
+	Status = "status"
+	// Hidden leaves the route out of the document, a bool.
+	Hidden = "hidden"
+)
+
+// Generate describes the routes of rtr. Routes for all methods, such as
+// mount points, and routes with anonymous catch-alls are left out.
+func Generate(rtr *router.Router, info Info) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]*PathItem{},
+	}
+
+	s := &schemas{components: map[string]*Schema{}, names: map[reflect.Type]string{}}
+
+	for _, route := range rtr.Routes() {
+		if route.Method == "" || route.Meta[Hidden] == true || strings.HasSuffix(route.Path, "/*") {
+			continue
+		}
+
+		path := Path(route.Path)
+
+		item := doc.Paths[path]
+		if item == nil {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+
+		item.SetOperation(route.Method, operation(route, s))
+	}
+
+	if len(s.components) > 0 {
+		doc.Components = &Components{Schemas: s.components}
+	}
+
+	return doc
+}
+
+// Serve registers GET routes serving the document of rtr as JSON at path +
+// ".json" and as YAML at path + ".yaml". The document is generated on the
+// first request, when all routes are registered. Its own routes are hidden.
+func Serve(rtr *router.Router, path string, info Info) {
+	var (
+		once     sync.Once
+		jsonBody []byte
+		yamlBody []byte
+		err      error
+	)
+
+	generate := func() {
+		doc := Generate(rtr, info)
+
+		if jsonBody, err = json.MarshalIndent(doc, "", "  "); err == nil {
+			yamlBody, err = yaml.Marshal(doc)
+		}
+	}
+
+	serve := func(contentType string, body *[]byte) router.HandlerFuncWithParam {
+		return func(w http.ResponseWriter, r *http.Request, params router.PathParams) {
+			once.Do(generate)
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", contentType)
+			w.Write(*body)
+		}
+	}
+
+	rtr.AddGet(path+".json", serve("application/json", &jsonBody)).Set(Hidden, true)
+	rtr.AddGet(path+".yaml", serve("application/yaml", &yamlBody)).Set(Hidden, true)
+}
+
+// Path translates a route path into an OpenAPI path, e.g. "/repos/:owner"
+// into "/repos/{owner}".
+func Path(routePath string) string {
+	segments := strings.Split(routePath, "/")
+
+	for i, segment := range segments {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			name, _, _ := strings.Cut(segment[1:], ":")
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func operation(route *router.Route, s *schemas) *Operation {
+	op := &Operation{
+		OperationID: metaString(route, OperationID),
+		Summary:     metaString(route, Summary),
+		Description: metaString(route, Description),
+		Responses:   map[string]*Response{},
+	}
+
+	if op.OperationID == "" {
+		op.OperationID = operationID(route)
+	}
+
+	op.Tags, _ = route.Meta[Tags].([]string)
+	op.Deprecated, _ = route.Meta[Deprecated].(bool)
+
+	var request reflect.Type
+	if value := route.Meta[RequestType]; value != nil {
+		request = reflect.TypeOf(value)
+		for request.Kind() == reflect.Ptr {
+			request = request.Elem()
+		}
+	}
+
+	for _, p := range route.Params() {
+		param := &Parameter{Name: p.Name, In: "path", Required: true, Schema: constraintSchema(p.Constraint)}
+
+		if field, ok := taggedField(request, "path", p.Name); ok {
+			param.Schema = s.of(field.Type)
+			constrain(param.Schema, field)
+		}
+
+		if p.CatchAll {
+			param.Description = "The rest of the path, slashes included."
+		}
+
+		op.Parameters = append(op.Parameters, param)
+	}
+
+	if request != nil && request.Kind() == reflect.Struct {
+		op.Parameters = append(op.Parameters, queryParameters(request, s)...)
+
+		if hasBody(request) {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]*MediaType{"application/json": {Schema: s.of(request)}},
+			}
+		}
+	}
+
+	status, ok := route.Meta[Status].(int)
+	if !ok {
+		status = http.StatusOK
+	}
+
+	response := &Response{Description: http.StatusText(status)}
+	if value := route.Meta[ResponseType]; value != nil {
+		response.Content = map[string]*MediaType{"application/json": {Schema: s.of(reflect.TypeOf(value))}}
+	}
+	op.Responses[strconv.Itoa(status)] = response
+
+	return op
+}
+
+func queryParameters(t reflect.Type, s *schemas) []*Parameter {
+	var params []*Parameter
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			params = append(params, queryParameters(field.Type, s)...)
+			continue
+		}
+
+		name := field.Tag.Get("query")
+		if name == "" {
+			continue
+		}
+
+		param := &Parameter{
+			Name:     name,
+			In:       "query",
+			Required: strings.Contains(","+field.Tag.Get("validate")+",", ",required,"),
+			Schema:   s.of(field.Type),
+		}
+		constrain(param.Schema, field)
+
+		params = append(params, param)
+	}
+
+	return params
+}
+
+// taggedField finds the field of struct type t tagged key:"name".
+func taggedField(t reflect.Type, key, name string) (reflect.StructField, bool) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if embedded, ok := taggedField(field.Type, key, name); ok {
+				return embedded, true
+			}
+		}
+
+		if field.Tag.Get(key) == name {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// hasBody reports whether struct type t has fields decoded from JSON.
+func hasBody(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Tag.Get("path") != "" || field.Tag.Get("query") != "" || field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if hasBody(field.Type) {
+				return true
+			}
+			continue
+		}
+
+		if field.IsExported() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func constraintSchema(constraint string) *Schema {
+	switch constraint {
+	case "":
+		return &Schema{Type: "string"}
+	case "[0-9]+", `\d+`:
+		return &Schema{Type: "integer"}
+	default:
+		// constraints match whole segments
+		return &Schema{Type: "string", Pattern: "^(?:" + constraint + ")$"}
+	}
+}
+
+func operationID(route *router.Route) string {
+	var id strings.Builder
+	id.WriteString(strings.ToLower(route.Method))
+
+	for _, segment := range strings.Split(route.Path, "/") {
+		if segment == "" {
+			continue
+		}
+
+		if segment[0] == ':' || segment[0] == '*' {
+			id.WriteString("By")
+			segment, _, _ = strings.Cut(segment[1:], ":")
+		}
+
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			id.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	return id.String()
+}
+
+func metaString(route *router.Route, key string) string {
+	s, _ := route.Meta[key].(string)
+	return s
+}
@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"github.com/shyamz-22/router"
+	"github.com/shyamz-22/router/assert"
+
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type listPulls struct {
+	Owner string   `path:"owner"`
+	State string   `query:"state" validate:"oneof=open closed"`
+	Page  int      `query:"page" validate:"required,min=1"`
+	Label []string `query:"label"`
+}
+
+type createPull struct {
+	Owner string `json:"-" path:"owner"`
+	Title string `json:"title" validate:"max=256"`
+	Body  string `json:"body,omitempty"`
+}
+
+type pull struct {
+	Number  int64      `json:"number"`
+	Title   string     `json:"title"`
+	Author  *user      `json:"author,omitempty"`
+	Merged  *time.Time `json:"merged"`
+	Reviews []user     `json:"reviews"`
+}
+
+type user struct {
+	Login string `json:"login"`
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	rtr := router.New()
+	noop := func(w http.ResponseWriter, r *http.Request, params router.PathParams) {}
+
+	rtr.AddGet("/repos/:owner/pulls", noop).
+		Set(Summary, "List pull requests").
+		Set(Tags, []string{"pulls"}).
+		Set(RequestType, listPulls{}).
+		Set(ResponseType, []pull{})
+
+	rtr.AddPost("/repos/:owner/pulls", noop).
+		Set(OperationID, "createPull").
+		Set(RequestType, &createPull{}).
+		Set(ResponseType, pull{}).
+		Set(Status, http.StatusCreated)
+
+	rtr.AddGet("/repos/:owner/pulls/:number:[0-9]+/files/*path", noop).Set(Deprecated, true)
+	rtr.AddDelete("/internal/cache", noop).Set(Hidden, true)
+	rtr.Mount("/admin", http.NotFoundHandler())
+
+	doc := Generate(rtr, Info{Title: "Pulls", Version: "1.0.0"})
+
+	t.Run("describes operations", func(t *testing.T) {
+		assertJSON(t, doc.Paths["/repos/{owner}/pulls"].Get, `{
+			"operationId": "getReposByOwnerPulls",
+			"summary": "List pull requests",
+			"tags": ["pulls"],
+			"parameters": [
+				{"name": "owner", "in": "path", "required": true, "schema": {"type": "string"}},
+				{"name": "state", "in": "query", "schema": {"type": "string", "enum": ["open", "closed"]}},
+				{"name": "page", "in": "query", "required": true, "schema": {"type": "integer", "minimum": 1}},
+				{"name": "label", "in": "query", "schema": {"type": "array", "items": {"type": "string"}}}
+			],
+			"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {
+				"type": "array", "items": {"$ref": "#/components/schemas/pull"}
+			}}}}}
+		}`)
+	})
+
+	t.Run("describes request bodies", func(t *testing.T) {
+		assertJSON(t, doc.Paths["/repos/{owner}/pulls"].Post, `{
+			"operationId": "createPull",
+			"parameters": [{"name": "owner", "in": "path", "required": true, "schema": {"type": "string"}}],
+			"requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/createPull"}}}},
+			"responses": {"201": {"description": "Created", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/pull"}}}}}
+		}`)
+	})
+
+	t.Run("types params by their constraint", func(t *testing.T) {
+		assertJSON(t, doc.Paths["/repos/{owner}/pulls/{number}/files/{path}"].Get.Parameters[1:], `[
+			{"name": "number", "in": "path", "required": true, "schema": {"type": "integer"}},
+			{"name": "path", "in": "path", "description": "The rest of the path, slashes included.", "required": true, "schema": {"type": "string"}}
+		]`)
+	})
+
+	t.Run("collects schemas of named types", func(t *testing.T) {
+		assertJSON(t, doc.Components.Schemas, `{
+			"createPull": {"type": "object", "properties": {
+				"title": {"type": "string", "maxLength": 256},
+				"body": {"type": "string"}
+			}, "required": ["title"]},
+			"pull": {"type": "object", "properties": {
+				"number": {"type": "integer", "format": "int64"},
+				"title": {"type": "string"},
+				"author": {"$ref": "#/components/schemas/user"},
+				"merged": {"type": ["string", "null"], "format": "date-time"},
+				"reviews": {"type": "array", "items": {"$ref": "#/components/schemas/user"}}
+			}, "required": ["number", "title", "reviews"]},
+			"user": {"type": "object", "properties": {"login": {"type": "string"}}, "required": ["login"]}
+		}`)
+	})
+
+	t.Run("leaves out hidden routes and mount points", func(t *testing.T) {
+		if len(doc.Paths) != 2 {
+			t.Errorf("Expected 2 paths but got %v", doc.Paths)
+		}
+	})
+
+	t.Run("constrains pointers by the type they point to", func(t *testing.T) {
+		type filter struct {
+			Name   *string   `json:"name" validate:"min=3"`
+			Labels *[]string `json:"labels" validate:"max=5"`
+			Size   *int      `json:"size" validate:"oneof=1 10"`
+		}
+
+		rtr := router.New()
+		rtr.AddPost("/filters", noop).Set(RequestType, filter{})
+
+		doc := Generate(rtr, Info{Title: "Filters", Version: "1.0.0"})
+
+		assertJSON(t, doc.Components.Schemas["filter"].Properties, `{
+			"name": {"type": ["string", "null"], "minLength": 3},
+			"labels": {"type": ["array", "null"], "items": {"type": "string"}, "maxItems": 5},
+			"size": {"type": ["integer", "null"], "enum": [1, 10]}
+		}`)
+	})
+
+	t.Run("tells apart types of the same name", func(t *testing.T) {
+		type user struct {
+			ID int `json:"id"`
+		}
+
+		rtr := router.New()
+		rtr.AddGet("/members", noop).Set(ResponseType, []user{})
+		rtr.AddGet("/pulls", noop).Set(ResponseType, []pull{})
+
+		doc := Generate(rtr, Info{Title: "Pulls", Version: "1.0.0"})
+
+		assertJSON(t, doc.Components.Schemas["user"], `{"type": "object", "properties": {"id": {"type": "integer"}}, "required": ["id"]}`)
+		assertJSON(t, doc.Components.Schemas["Openapiuser"], `{"type": "object", "properties": {"login": {"type": "string"}}, "required": ["login"]}`)
+		assertJSON(t, doc.Components.Schemas["pull"].Properties["author"], `{"$ref": "#/components/schemas/Openapiuser"}`)
+	})
+}
+
+func TestServe(t *testing.T) {
+	t.Parallel()
+	rtr := router.New()
+	rtr.AddGet("/pings/:id", func(w http.ResponseWriter, r *http.Request, params router.PathParams) {})
+
+	Serve(rtr, "/openapi", Info{Title: "Pings", Version: "1.0.0"})
+
+	t.Run("serves JSON", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusOK)
+		assert.ResponseWithHeader(t, w, "Content-Type", "application/json")
+
+		var doc Document
+		if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil || doc.OpenAPI != "3.1.0" || len(doc.Paths) != 1 {
+			t.Errorf("Unexpected document %s, %v", w.Body, err)
+		}
+	})
+
+	t.Run("serves YAML", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/openapi.yaml", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, `openapi: 3.1.0
+info:
+    title: Pings
+    version: 1.0.0
+paths:
+    /pings/{id}:
+        get:
+            operationId: getPingsById
+            parameters:
+                - name: id
+                  in: path
+                  required: true
+                  schema:
+                    type: string
+            responses:
+                "200":
+                    description: OK
+`)
+	})
+}
+
+func assertJSON(t *testing.T, actual interface{}, expected string) {
+	t.Helper()
+
+	var want interface{}
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := json.Marshal(actual)
+	wantJSON, _ := json.Marshal(want)
+
+	var gotNormalized interface{}
+	json.Unmarshal(got, &gotNormalized)
+	gotJSON, _ := json.Marshal(gotNormalized)
+
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("\nExpected: %s\nActual:   %s", strings.TrimSpace(string(wantJSON)), gotJSON)
+	}
+}
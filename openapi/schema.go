@@ -0,0 +1,225 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shyamz-22/router"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(router.UUID{})
+)
+
+// schemas derives schemas of Go types. Named struct types are collected as
+// components and referenced.
+type schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func (s *schemas) of(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := s.of(t.Elem())
+		if typ, ok := schema.Type.(string); ok {
+			schema.Type = []string{typ, "null"}
+		}
+		return schema
+
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+
+	case reflect.String:
+		return &Schema{Type: "string"}
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+
+		name, ok := s.names[t]
+		if !ok {
+			// reserve the name first, the type may refer to itself
+			name = s.name(t)
+			s.names[t] = name
+			s.components[name] = nil
+			s.components[name] = s.object(t)
+		}
+
+		return &Schema{Ref: "#/components/schemas/" + name}
+
+	default:
+		return &Schema{}
+	}
+}
+
+// name returns the component name of named type t, its name unless another
+// type of that name was described already. Then the name of its package
+// is put in front, e.g. AdminUser, and a number appended if still taken.
+func (s *schemas) name(t reflect.Type) string {
+	name := t.Name()
+	if _, taken := s.components[name]; !taken {
+		return name
+	}
+
+	pkg := t.PkgPath()
+	if i := strings.LastIndexByte(pkg, '/'); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+
+	if pkg != "" {
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+
+	for i, base := 2, name; ; i++ {
+		if _, taken := s.components[name]; !taken {
+			return name
+		}
+
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// object describes the JSON fields of struct type t. Fields bound from path
+// or query params are left out. Fields without omitempty are required.
+func (s *schemas) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	s.fields(t, schema)
+
+	return schema
+}
+
+func (s *schemas) fields(t reflect.Type, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Tag.Get("path") != "" || field.Tag.Get("query") != "" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			s.fields(field.Type, schema)
+			continue
+		}
+
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := s.of(field.Type)
+		constrain(property, field)
+		schema.Properties[name] = property
+
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// constrain adds the rules of the `validate` tag of field to schema, see
+// router.PathParams.Bind.
+func constrain(schema *Schema, field reflect.StructField) {
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		rule, arg, _ := strings.Cut(rule, "=")
+
+		switch rule {
+		case "min", "max":
+			bound, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				continue
+			}
+
+			switch baseType(schema) {
+			case "string":
+				n := int(bound)
+				if rule == "min" {
+					schema.MinLength = &n
+				} else {
+					schema.MaxLength = &n
+				}
+			case "array":
+				n := int(bound)
+				if rule == "min" {
+					schema.MinItems = &n
+				} else {
+					schema.MaxItems = &n
+				}
+			default:
+				if rule == "min" {
+					schema.Minimum = &bound
+				} else {
+					schema.Maximum = &bound
+				}
+			}
+
+		case "oneof":
+			typ := baseType(schema)
+
+			for _, option := range strings.Fields(arg) {
+				if n, err := strconv.ParseFloat(option, 64); err == nil && (typ == "integer" || typ == "number") {
+					schema.Enum = append(schema.Enum, n)
+				} else {
+					schema.Enum = append(schema.Enum, option)
+				}
+			}
+		}
+	}
+}
+
+// baseType returns the type of schema, leaving out the "null" of the schemas
+// of pointers.
+func baseType(schema *Schema) string {
+	switch typ := schema.Type.(type) {
+	case string:
+		return typ
+	case []string:
+		for _, t := range typ {
+			if t != "null" {
+				return t
+			}
+		}
+	}
+
+	return ""
+}
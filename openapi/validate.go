@@ -0,0 +1,423 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/shyamz-22/router"
+)
+
+// Validation checks requests, and optionally responses, against the
+// operations of an OpenAPI document:
+//
+//	v := &openapi.Validation{Document: doc}
+//	rtr.Use(v.Middleware)
+//
+// Path, query and header parameters and JSON request bodies are validated
+// by a self-contained evaluator of a JSON Schema subset, see Schema. Routes
+// are matched to operations by method and Path, routes without an
+// operation are left alone.
+type Validation struct {
+	Document *Document
+
+	// Responses buffers responses and validates their status and JSON body.
+	// Invalid responses are replaced by 500 Internal Server Error listing
+	// the violations. Meant for tests, as it defeats streaming.
+	Responses bool
+
+	// ErrorHandler answers invalid requests, and responses if Responses is
+	// set, with the *ValidationError. If nil, the error is rendered as
+	// problem details by a router.ErrorRenderer, with the violations as
+	// member "errors".
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// FieldError is a violation of the API description.
+type FieldError struct {
+	// In is "path", "query", "header", "body" or "response".
+	In string `json:"in"`
+	// Name is the name of the parameter.
+	Name string `json:"name,omitempty"`
+	// Pointer is the JSON pointer to the invalid part of a body.
+	Pointer string `json:"pointer,omitempty"`
+	Detail  string `json:"detail"`
+}
+
+func (e *FieldError) Error() string {
+	switch {
+	case e.Name != "":
+		return fmt.Sprintf("%s param %q %s", e.In, e.Name, e.Detail)
+	case e.Pointer != "":
+		return fmt.Sprintf("%s %s %s", e.In, e.Pointer, e.Detail)
+	default:
+		return e.In + " " + e.Detail
+	}
+}
+
+// ValidationError lists the violations of a request or response.
+type ValidationError struct {
+	Status int
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// StatusCode is 400 Bad Request for requests and 500 Internal Server Error
+// for responses.
+func (e *ValidationError) StatusCode() int {
+	return e.Status
+}
+
+// Middleware validates the requests of route if the document describes it.
+func (v *Validation) Middleware(route *router.Route, next router.HandlerFuncWithParam) router.HandlerFuncWithParam {
+	item := v.Document.Paths[Path(route.Path)]
+	if item == nil || route.Method == "" {
+		return next
+	}
+
+	op := item.Operations()[route.Method]
+	if op == nil {
+		return next
+	}
+
+	e := &evaluator{doc: v.Document}
+	params := append(append([]*Parameter{}, item.Parameters...), op.Parameters...)
+
+	return func(w http.ResponseWriter, r *http.Request, pathParams router.PathParams) {
+		errs := e.parameters(params, r, pathParams)
+
+		bodyErrs, err := e.requestBody(op.RequestBody, r)
+		if err != nil {
+			v.handleError(w, r, err)
+			return
+		}
+
+		if errs = append(errs, bodyErrs...); len(errs) > 0 {
+			v.handleError(w, r, &ValidationError{Status: http.StatusBadRequest, Errors: errs})
+			return
+		}
+
+		if !v.Responses {
+			next(w, r, pathParams)
+			return
+		}
+
+		recorder := &responseRecorder{header: http.Header{}}
+		next(recorder, r, pathParams)
+
+		if errs := e.response(op, recorder); len(errs) > 0 {
+			v.handleError(w, r, &ValidationError{Status: http.StatusInternalServerError, Errors: errs})
+			return
+		}
+
+		recorder.copyTo(w)
+	}
+}
+
+func (v *Validation) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	if v.ErrorHandler != nil {
+		v.ErrorHandler(w, r, err)
+		return
+	}
+
+	problem := &router.Problem{Status: http.StatusBadRequest, Detail: err.Error()}
+
+	var coder router.StatusCoder
+	if errors.As(err, &coder) {
+		problem.Status = coder.StatusCode()
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		problem.Detail = "the request does not match the API description"
+		if problem.Status >= http.StatusInternalServerError {
+			problem.Detail = "the response does not match the API description"
+		}
+
+		problem.Extensions = map[string]interface{}{"errors": validationErr.Errors}
+	}
+
+	(&router.ErrorRenderer{}).Render(w, r, problem)
+}
+
+func (e *evaluator) parameters(params []*Parameter, r *http.Request, pathParams router.PathParams) []*FieldError {
+	var errs []*FieldError
+
+	for _, param := range params {
+		var (
+			values  []string
+			present bool
+		)
+
+		switch param.In {
+		case "path":
+			var value string
+			value, present = pathParams.Get(param.Name)
+			values = []string{value}
+		case "query":
+			values, present = r.URL.Query()[param.Name]
+		case "header":
+			values = r.Header.Values(param.Name)
+			present = len(values) > 0
+		default:
+			continue
+		}
+
+		if !present {
+			if param.Required {
+				errs = append(errs, &FieldError{In: param.In, Name: param.Name, Detail: "is required"})
+			}
+			continue
+		}
+
+		value, err := e.coerce(param.Schema, values)
+		if err != nil {
+			errs = append(errs, &FieldError{In: param.In, Name: param.Name, Detail: err.Error()})
+			continue
+		}
+
+		for _, violation := range e.validate(param.Schema, value, "") {
+			errs = append(errs, &FieldError{In: param.In, Name: param.Name, Pointer: violation.pointer, Detail: violation.detail})
+		}
+	}
+
+	return errs
+}
+
+// coerce converts the string values of a parameter into the JSON value its
+// schema describes. Arrays take the values of repeated parameters, or the
+// comma separated values of a single one.
+func (e *evaluator) coerce(s *Schema, values []string) (interface{}, error) {
+	s = e.deref(s)
+
+	if s != nil && contains(schemaTypes(s), "array") {
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+
+		items := make([]interface{}, len(values))
+		for i, value := range values {
+			item, err := e.coerce(s.Items, []string{value})
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+
+		return items, nil
+	}
+
+	value := values[0]
+	if s == nil {
+		return value, nil
+	}
+
+	types := schemaTypes(s)
+
+	switch {
+	case contains(types, "integer") || contains(types, "number"):
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil && !contains(types, "string") {
+			return nil, fmt.Errorf("must be of type %s", strings.Join(types, " or "))
+		}
+		if err == nil {
+			return n, nil
+		}
+
+	case contains(types, "boolean"):
+		b, err := strconv.ParseBool(value)
+		if err != nil && !contains(types, "string") {
+			return nil, errors.New("must be of type boolean")
+		}
+		if err == nil {
+			return b, nil
+		}
+	}
+
+	return value, nil
+}
+
+func (e *evaluator) requestBody(body *RequestBody, r *http.Request) ([]*FieldError, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		if body.Required {
+			return []*FieldError{{In: "body", Detail: "is required"}}, nil
+		}
+		return nil, nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+
+	media, ok := mediaTypeOf(body.Content, contentType)
+	if !ok {
+		return []*FieldError{{In: "body", Detail: fmt.Sprintf("content type %q is not allowed", contentType)}}, nil
+	}
+
+	if media == nil || media.Schema == nil || !isJSON(contentType) {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, router.MaxJSONBodyBytes))
+	r.Body.Close()
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return nil, router.ErrorWithStatus(http.StatusRequestEntityTooLarge,
+			fmt.Errorf("body must not be larger than %d bytes", tooLarge.Limit))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the handler reads the body again
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	return e.document("body", media.Schema, data), nil
+}
+
+func (e *evaluator) response(op *Operation, recorder *responseRecorder) []*FieldError {
+	status := recorder.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	response, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		response, ok = op.Responses[strconv.Itoa(status/100)+"XX"]
+	}
+	if !ok {
+		response, ok = op.Responses["default"]
+	}
+	if !ok {
+		return []*FieldError{{In: "response", Detail: fmt.Sprintf("status %d is not described", status)}}
+	}
+
+	if response == nil || len(response.Content) == 0 || recorder.body.Len() == 0 {
+		return nil
+	}
+
+	contentType := recorder.header.Get("Content-Type")
+
+	media, ok := mediaTypeOf(response.Content, contentType)
+	if !ok {
+		return []*FieldError{{In: "response", Detail: fmt.Sprintf("content type %q is not described", contentType)}}
+	}
+
+	if media == nil || media.Schema == nil || !isJSON(contentType) {
+		return nil
+	}
+
+	return e.document("response", media.Schema, recorder.body.Bytes())
+}
+
+// document validates the JSON document data against s.
+func (e *evaluator) document(in string, s *Schema, data []byte) []*FieldError {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return []*FieldError{{In: in, Detail: "is not valid JSON: " + err.Error()}}
+	}
+
+	var errs []*FieldError
+	for _, violation := range e.validate(s, value, "") {
+		pointer := violation.pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+
+		errs = append(errs, &FieldError{In: in, Pointer: pointer, Detail: violation.detail})
+	}
+
+	return errs
+}
+
+// mediaTypeOf finds the media type of content matching contentType, trying
+// the exact type, then "type/*" and "*/*".
+func mediaTypeOf(content map[string]*MediaType, contentType string) (*MediaType, bool) {
+	if len(content) == 0 {
+		return nil, true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+
+	major, _, _ := strings.Cut(mediaType, "/")
+
+	for _, key := range []string{mediaType, major + "/*", "*/*"} {
+		if media, ok := content[key]; ok {
+			return media, true
+		}
+	}
+
+	return nil, false
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// responseRecorder buffers a response until it is validated.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+
+	return rec.body.Write(b)
+}
+
+func (rec *responseRecorder) copyTo(w http.ResponseWriter) {
+	for key, values := range rec.header {
+		w.Header()[key] = values
+	}
+
+	if rec.status != 0 {
+		w.WriteHeader(rec.status)
+	}
+
+	w.Write(rec.body.Bytes())
+}
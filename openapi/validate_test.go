@@ -0,0 +1,235 @@
+package openapi
+
+import (
+	"github.com/shyamz-22/router"
+	"github.com/shyamz-22/router/assert"
+
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const pullsSpec = `
+openapi: 3.1.0
+info:
+  title: Pulls
+  version: 1.0.0
+paths:
+  /repos/{owner}/pulls:
+    parameters:
+      - name: owner
+        in: path
+        required: true
+        schema:
+          type: string
+          pattern: ^[a-z]+$
+    get:
+      operationId: listPulls
+      parameters:
+        - name: state
+          in: query
+          schema:
+            type: string
+            enum: [open, closed]
+        - name: page
+          in: query
+          schema:
+            type: integer
+            minimum: 1
+        - name: label
+          in: query
+          schema:
+            type: array
+            maxItems: 2
+            items:
+              type: string
+        - name: X-Api-Version
+          in: header
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Pull"
+    post:
+      operationId: createPull
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/NewPull"
+      responses:
+        "201":
+          description: Created
+components:
+  schemas:
+    Pull:
+      type: object
+      required: [number, title]
+      properties:
+        number:
+          type: integer
+        title:
+          type: string
+    NewPull:
+      type: object
+      required: [title]
+      additionalProperties: false
+      properties:
+        title:
+          type: string
+          minLength: 1
+        draft:
+          type: boolean
+        reviewers:
+          type: array
+          items:
+            type: string
+            format: uuid
+`
+
+func TestValidation(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Load(strings.NewReader(pullsSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRouter := func(v *Validation, pulls string) *router.Router {
+		rtr := router.New()
+
+		err := Bind(rtr, doc, Handlers{
+			"listPulls": func(w http.ResponseWriter, r *http.Request, params router.PathParams) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(pulls))
+			},
+			"createPull": func(w http.ResponseWriter, r *http.Request, params router.PathParams) {
+				body, _ := io.ReadAll(r.Body)
+
+				w.WriteHeader(http.StatusCreated)
+				w.Write(body)
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rtr.Use(v.Middleware)
+
+		return rtr
+	}
+
+	serve := func(rtr *router.Router, method, path, body string, header http.Header) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(method, path, strings.NewReader(body))
+		for key, values := range header {
+			r.Header[key] = values
+		}
+
+		rtr.ServeHTTP(w, r)
+
+		return w
+	}
+
+	versioned := http.Header{"X-Api-Version": {"1"}}
+	jsonBody := http.Header{"Content-Type": {"application/json"}}
+
+	t.Run("passes valid requests", func(t *testing.T) {
+		rtr := newRouter(&Validation{Document: doc}, `[]`)
+
+		w := serve(rtr, http.MethodGet, "/repos/golang/pulls?state=open&page=2&label=a&label=b", "", versioned)
+		assert.ResponseWithBody(t, w, http.StatusOK, `[]`)
+
+		w = serve(rtr, http.MethodPost, "/repos/golang/pulls", `{"title":"Fix","reviewers":["6ba7b810-9dad-11d1-80b4-00c04fd430c8"]}`, jsonBody)
+		assert.ResponseWithBody(t, w, http.StatusCreated, `{"title":"Fix","reviewers":["6ba7b810-9dad-11d1-80b4-00c04fd430c8"]}`)
+	})
+
+	t.Run("rejects invalid params", func(t *testing.T) {
+		rtr := newRouter(&Validation{Document: doc}, `[]`)
+
+		w := serve(rtr, http.MethodGet, "/repos/Go/pulls?state=draft&page=0&label=a,b,c", "", nil)
+
+		assert.ResponseWithStatus(t, w, http.StatusBadRequest)
+		assert.ResponseWithHeader(t, w, "Content-Type", "application/problem+json")
+		assert.ResponseWithBody(t, w, http.StatusBadRequest, `{"detail":"the request does not match the API description","errors":[`+
+			`{"in":"path","name":"owner","detail":"must match pattern \"^[a-z]+$\""},`+
+			`{"in":"query","name":"state","detail":"must be one of [open closed]"},`+
+			`{"in":"query","name":"page","detail":"must be at least 1"},`+
+			`{"in":"query","name":"label","detail":"must have at most 2 items"},`+
+			`{"in":"header","name":"X-Api-Version","detail":"is required"}],`+
+			`"instance":"/repos/Go/pulls","status":400,"title":"Bad Request","type":"about:blank"}`+"\n")
+	})
+
+	t.Run("rejects params of the wrong type", func(t *testing.T) {
+		rtr := newRouter(&Validation{Document: doc}, `[]`)
+
+		w := serve(rtr, http.MethodGet, "/repos/golang/pulls?page=last", "", versioned)
+
+		assert.ResponseWithBody(t, w, http.StatusBadRequest, `{"detail":"the request does not match the API description","errors":[`+
+			`{"in":"query","name":"page","detail":"must be of type integer"}],`+
+			`"instance":"/repos/golang/pulls","status":400,"title":"Bad Request","type":"about:blank"}`+"\n")
+	})
+
+	t.Run("rejects invalid bodies", func(t *testing.T) {
+		rtr := newRouter(&Validation{Document: doc}, `[]`)
+
+		w := serve(rtr, http.MethodPost, "/repos/golang/pulls", `{"draft":"yes","reviewers":["me"],"labels":[]}`, jsonBody)
+
+		assert.ResponseWithBody(t, w, http.StatusBadRequest, `{"detail":"the request does not match the API description","errors":[`+
+			`{"in":"body","pointer":"/draft","detail":"must be of type boolean"},`+
+			`{"in":"body","pointer":"/labels","detail":"must not match the schema of not"},`+
+			`{"in":"body","pointer":"/reviewers/0","detail":"must be a valid uuid"},`+
+			`{"in":"body","pointer":"/title","detail":"is required"}],`+
+			`"instance":"/repos/golang/pulls","status":400,"title":"Bad Request","type":"about:blank"}`+"\n")
+	})
+
+	t.Run("requires bodies of the described content type", func(t *testing.T) {
+		rtr := newRouter(&Validation{Document: doc}, `[]`)
+
+		w := serve(rtr, http.MethodPost, "/repos/golang/pulls", "", jsonBody)
+		assert.ResponseWithStatus(t, w, http.StatusBadRequest)
+
+		w = serve(rtr, http.MethodPost, "/repos/golang/pulls", "title=Fix", http.Header{"Content-Type": {"application/x-www-form-urlencoded"}})
+		assert.ResponseWithStatus(t, w, http.StatusBadRequest)
+	})
+
+	t.Run("validates responses in test mode", func(t *testing.T) {
+		rtr := newRouter(&Validation{Document: doc, Responses: true}, `[{"number":1.5}]`)
+
+		w := serve(rtr, http.MethodGet, "/repos/golang/pulls", "", versioned)
+
+		assert.ResponseWithBody(t, w, http.StatusInternalServerError, `{"detail":"the response does not match the API description","errors":[`+
+			`{"in":"response","pointer":"/0/number","detail":"must be of type integer"},`+
+			`{"in":"response","pointer":"/0/title","detail":"is required"}],`+
+			`"instance":"/repos/golang/pulls","status":500,"title":"Internal Server Error","type":"about:blank"}`+"\n")
+	})
+
+	t.Run("passes valid responses in test mode", func(t *testing.T) {
+		rtr := newRouter(&Validation{Document: doc, Responses: true}, `[{"number":1,"title":"Fix"}]`)
+
+		w := serve(rtr, http.MethodGet, "/repos/golang/pulls", "", versioned)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, `[{"number":1,"title":"Fix"}]`)
+		assert.ResponseWithHeader(t, w, "Content-Type", "application/json")
+	})
+
+	t.Run("hands errors to the ErrorHandler", func(t *testing.T) {
+		rtr := newRouter(&Validation{Document: doc, ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), err.(*ValidationError).StatusCode())
+		}}, `[]`)
+
+		w := serve(rtr, http.MethodGet, "/repos/golang/pulls?page=0", "", versioned)
+
+		assert.ResponseWithBody(t, w, http.StatusBadRequest, "query param \"page\" must be at least 1\n")
+	})
+}
@@ -0,0 +1,115 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouteWithAutomaticOptions(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddGet("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pong)
+	})
+
+	rtr.AddPost("/pings/:id", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	rtr.AddDelete("/pings/:id", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	rtr.AddOptions("/pongs", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pongBack)
+	})
+
+	t.Run("returns 204 with allowed methods", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodOptions, "/pings/1", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNoContent)
+		assert.ResponseWithHeader(t, w, "Allow", "DELETE, OPTIONS, POST")
+	})
+
+	t.Run("prefers an explicit OPTIONS route", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodOptions, "/pongs", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "Pong back!")
+	})
+
+	t.Run("returns all registered methods for asterisk", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodOptions, "*", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNoContent)
+		assert.ResponseWithHeader(t, w, "Allow", "DELETE, GET, HEAD, OPTIONS, POST")
+	})
+
+	t.Run("returns 404 for unknown path", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodOptions, "/unknown", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNotFound)
+	})
+
+	t.Run("sets allowed methods on 405", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodPut, "/pings/1", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusMethodNotAllowed)
+		assert.ResponseWithHeader(t, w, "Allow", "DELETE, OPTIONS, POST")
+	})
+}
+
+func TestRouteWithGlobalOptions(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddGet("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pong)
+	})
+
+	rtr.GlobalOPTIONS = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Methods", w.Header().Get("Allow"))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodOptions, "/pings", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	assert.ResponseWithStatus(t, w, http.StatusOK)
+	assert.ResponseWithHeader(t, w, "Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
+}
+
+func TestRouteWithoutAutomaticOptions(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.HandleOPTIONS = false
+	rtr.AddGet("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pong)
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodOptions, "/pings", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	assert.ResponseWithStatus(t, w, http.StatusMethodNotAllowed)
+	assert.ResponseWithHeader(t, w, "Allow", "GET, HEAD")
+}
@@ -0,0 +1,44 @@
+package router
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// DefaultPanicHandler logs the recovered value with the stack trace of the
+// panicking handler and answers with 500 Internal Server Error, unless the
+// handler already sent the response headers.
+func DefaultPanicHandler(w http.ResponseWriter, r *http.Request, recovered interface{}) {
+	logPanic(r, recovered)
+
+	if headerWritten(w) {
+		return
+	}
+
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+func logPanic(r *http.Request, recovered interface{}) {
+	slog.ErrorContext(r.Context(), "router: panic serving request",
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Any("panic", recovered),
+		slog.String("stack", string(debug.Stack())),
+	)
+}
+
+// recover hands a panic of the handler serving r to the PanicHandler.
+// http.ErrAbortHandler is re-panicked, as net/http uses it to abort the
+// response without logging.
+func (rtr *Router) recover(w *statusWriter, r *http.Request) {
+	if recovered := recover(); recovered != nil {
+		if recovered == http.ErrAbortHandler {
+			panic(recovered)
+		}
+
+		rtr.PanicHandler(w, r, recovered)
+	}
+
+	releaseStatusWriter(w)
+}
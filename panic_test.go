@@ -0,0 +1,153 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouteWithPanicHandler(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddGet("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		panic("ping failed")
+	})
+
+	rtr.AddGet("/pongs", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.WriteHeader(http.StatusAccepted)
+		panic("pong failed")
+	})
+
+	rtr.AddGet("/aborts", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		panic(http.ErrAbortHandler)
+	})
+
+	t.Run("returns 500 by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusInternalServerError, "Internal Server Error\n")
+	})
+
+	t.Run("returns 500 for HEAD by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodHead, "/pings", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusInternalServerError)
+	})
+
+	t.Run("keeps headers that were already sent", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pongs", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusAccepted, "")
+	})
+
+	t.Run("re-panics on abort", func(t *testing.T) {
+		defer func() {
+			if recovered := recover(); recovered != http.ErrAbortHandler {
+				t.Fatalf("\nExpected: %v\nActual:%v\n", http.ErrAbortHandler, recovered)
+			}
+		}()
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/aborts", nil)
+
+		rtr.ServeHTTP(w, r)
+	})
+}
+
+func TestRouteWithCustomPanicHandler(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddGet("/pings/:id", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		panic(params.ByName("id"))
+	})
+
+	rtr.PanicHandler = func(w http.ResponseWriter, r *http.Request, recovered interface{}) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, "recovered %v", recovered)
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/pings/1", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	assert.ResponseWithBody(t, w, http.StatusServiceUnavailable, "recovered 1")
+}
+
+func TestRouteWithoutPanicHandler(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.PanicHandler = nil
+	rtr.AddGet("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		panic("ping failed")
+	})
+
+	defer func() {
+		if recovered := recover(); recovered != "ping failed" {
+			t.Fatalf("\nExpected: ping failed\nActual:%v\n", recovered)
+		}
+	}()
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/pings", nil)
+
+	rtr.ServeHTTP(w, r)
+}
+
+func TestRouteWithPanicHandlerKeepsWriterInterfaces(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddGet("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		_, flusher := w.(http.Flusher)
+		_, hijacker := w.(http.Hijacker)
+		_, readerFrom := w.(io.ReaderFrom)
+		fmt.Fprintf(w, "flusher %v, hijacker %v, readerFrom %v", flusher, hijacker, readerFrom)
+	})
+
+	t.Run("advertises what the writer supports", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "flusher true, hijacker false, readerFrom false")
+	})
+
+	t.Run("advertises nothing the writer lacks", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings", nil)
+
+		rtr.ServeHTTP(struct{ http.ResponseWriter }{w}, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "flusher false, hijacker false, readerFrom false")
+	})
+
+	t.Run("advertises all interfaces of a connection", func(t *testing.T) {
+		server := httptest.NewServer(rtr)
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/pings")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != "flusher true, hijacker true, readerFrom true" {
+			t.Errorf("Unexpected body %s", body)
+		}
+	})
+}
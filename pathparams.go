@@ -1,5 +1,13 @@
 package router
 
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
 type Param struct {
 	Key   string
 	Value string
@@ -7,6 +15,39 @@ type Param struct {
 
 type PathParams []Param
 
+// ErrParamNotFound is wrapped by the ParamError of a param the route does
+// not have.
+var ErrParamNotFound = errors.New("not found")
+
+// ParamError describes a path or query param that is missing, not of the
+// requested type or invalid.
+type ParamError struct {
+	// In is "path" or "query", empty for path params.
+	In    string
+	Name  string
+	Value string
+	// Type is the requested type, empty if the value failed validation.
+	Type string
+	Err  error
+}
+
+func (e *ParamError) Error() string {
+	in := e.In
+	if in == "" {
+		in = "path"
+	}
+
+	if e.Type == "" || errors.Is(e.Err, ErrParamNotFound) {
+		return fmt.Sprintf("%s param %q: %v", in, e.Name, e.Err)
+	}
+
+	return fmt.Sprintf("%s param %q: %q is not a valid %s", in, e.Name, e.Value, e.Type)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
 func (params PathParams) ByName(name string) string {
 	for _, p := range params {
 		if p.Key == name {
@@ -16,3 +57,174 @@ func (params PathParams) ByName(name string) string {
 
 	return ""
 }
+
+// Get returns the value of the param name and whether the route has it. The
+// value of a present param may still be empty, e.g. of a catch-all.
+func (params PathParams) Get(name string) (string, bool) {
+	for _, p := range params {
+		if p.Key == name {
+			return p.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Len returns the number of params.
+func (params PathParams) Len() int {
+	return len(params)
+}
+
+// Map returns the params by name.
+func (params PathParams) Map() map[string]string {
+	m := make(map[string]string, len(params))
+
+	for _, p := range params {
+		m[p.Key] = p.Value
+	}
+
+	return m
+}
+
+// Int returns the value of the param name as int.
+func (params PathParams) Int(name string) (int, error) {
+	i, err := params.parseInt(name, "int", 0)
+	return int(i), err
+}
+
+// Int64 returns the value of the param name as int64.
+func (params PathParams) Int64(name string) (int64, error) {
+	return params.parseInt(name, "int64", 64)
+}
+
+// Uint returns the value of the param name as uint.
+func (params PathParams) Uint(name string) (uint, error) {
+	value, err := params.lookup(name, "uint")
+	if err != nil {
+		return 0, err
+	}
+
+	u, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		return 0, &ParamError{Name: name, Value: value, Type: "uint", Err: err}
+	}
+
+	return uint(u), nil
+}
+
+// Bool returns the value of the param name as bool. It accepts the values of
+// strconv.ParseBool.
+func (params PathParams) Bool(name string) (bool, error) {
+	value, err := params.lookup(name, "bool")
+	if err != nil {
+		return false, err
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, &ParamError{Name: name, Value: value, Type: "bool", Err: err}
+	}
+
+	return b, nil
+}
+
+// UUID returns the value of the param name as UUID, which has to be in the
+// canonical form, e.g. "123e4567-e89b-12d3-a456-426614174000".
+func (params PathParams) UUID(name string) (UUID, error) {
+	value, err := params.lookup(name, "uuid")
+	if err != nil {
+		return UUID{}, err
+	}
+
+	u, err := ParseUUID(value)
+	if err != nil {
+		return UUID{}, &ParamError{Name: name, Value: value, Type: "uuid", Err: err}
+	}
+
+	return u, nil
+}
+
+// Time returns the value of the param name as time.Time in layout.
+func (params PathParams) Time(name, layout string) (time.Time, error) {
+	value, err := params.lookup(name, "time")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, &ParamError{Name: name, Value: value, Type: "time in layout " + layout, Err: err}
+	}
+
+	return t, nil
+}
+
+func (params PathParams) parseInt(name, typ string, bitSize int) (int64, error) {
+	value, err := params.lookup(name, typ)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := strconv.ParseInt(value, 10, bitSize)
+	if err != nil {
+		return 0, &ParamError{Name: name, Value: value, Type: typ, Err: err}
+	}
+
+	return i, nil
+}
+
+func (params PathParams) lookup(name, typ string) (string, error) {
+	value, ok := params.Get(name)
+	if !ok {
+		return "", &ParamError{Name: name, Type: typ, Err: ErrParamNotFound}
+	}
+
+	return value, nil
+}
+
+// UUID is a universally unique identifier as defined in RFC 4122.
+type UUID [16]byte
+
+// ParseUUID parses a UUID in the canonical form.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, errors.New("invalid UUID format")
+	}
+
+	hexDigits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(u[:], []byte(hexDigits)); err != nil {
+		return UUID{}, err
+	}
+
+	return u, nil
+}
+
+// UnmarshalText parses a UUID in the canonical form.
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*u = parsed
+
+	return nil
+}
+
+func (u UUID) String() string {
+	var buf [36]byte
+
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:36], u[10:16])
+
+	return string(buf[:])
+}
@@ -0,0 +1,146 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPathParams(t *testing.T) {
+	t.Parallel()
+	params := PathParams{
+		{Key: "id", Value: "42"},
+		{Key: "big", Value: "9007199254740993"},
+		{Key: "negative", Value: "-1"},
+		{Key: "draft", Value: "true"},
+		{Key: "uuid", Value: "123e4567-e89b-12d3-a456-426614174000"},
+		{Key: "day", Value: "2024-03-01"},
+		{Key: "path", Value: ""},
+	}
+
+	t.Run("tells absent from empty params", func(t *testing.T) {
+		if value, ok := params.Get("path"); !ok || value != "" {
+			t.Errorf("Expected empty present param but got %q, %v", value, ok)
+		}
+
+		if _, ok := params.Get("missing"); ok {
+			t.Error("Expected absent param")
+		}
+	})
+
+	t.Run("converts params", func(t *testing.T) {
+		id, err := params.Int("id")
+		assertParam(t, id, 42, err)
+
+		big, err := params.Int64("big")
+		assertParam(t, big, int64(9007199254740993), err)
+
+		count, err := params.Uint("id")
+		assertParam(t, count, uint(42), err)
+
+		draft, err := params.Bool("draft")
+		assertParam(t, draft, true, err)
+
+		uuid, err := params.UUID("uuid")
+		assertParam(t, uuid.String(), "123e4567-e89b-12d3-a456-426614174000", err)
+
+		day, err := params.Time("day", time.DateOnly)
+		assertParam(t, day, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), err)
+	})
+
+	t.Run("describes invalid params", func(t *testing.T) {
+		_, err := params.Uint("negative")
+
+		var paramErr *ParamError
+		if !errors.As(err, &paramErr) || !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("Expected ParamError wrapping the parse error but got %v", err)
+		}
+
+		if err.Error() != `path param "negative": "-1" is not a valid uint` {
+			t.Errorf("Unexpected error message %q", err)
+		}
+
+		if _, err := params.UUID("id"); err == nil || err.Error() != `path param "id": "42" is not a valid uuid` {
+			t.Errorf("Unexpected error %v", err)
+		}
+	})
+
+	t.Run("describes missing params", func(t *testing.T) {
+		_, err := params.Int("missing")
+
+		if !errors.Is(err, ErrParamNotFound) || err.Error() != `path param "missing": not found` {
+			t.Errorf("Unexpected error %v", err)
+		}
+	})
+
+	t.Run("returns params by name", func(t *testing.T) {
+		m := params.Map()
+
+		if params.Len() != 7 || len(m) != 7 || m["draft"] != "true" {
+			t.Errorf("Unexpected params %v", m)
+		}
+	})
+}
+
+func TestRouteParamByPosition(t *testing.T) {
+	t.Parallel()
+	var route *Route
+
+	sub := New()
+	route = sub.AddGet("/repos/:repo:[a-z]+/files/*path", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		owner, _ := params.Get("owner")
+		repo, _ := route.Param(params, "repo")
+		path, _ := route.Param(params, "path")
+		_, ok := route.Param(params, "owner")
+
+		w.Write([]byte(owner + " " + repo + " " + path + " " + strconv.FormatBool(ok)))
+	})
+
+	rtr := New()
+	rtr.Mount("/users/:owner", sub)
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/users/gopher/repos/router/files/a/b.go", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	if body := w.Body.String(); body != "gopher router a/b.go false" {
+		t.Errorf("Unexpected body %q", body)
+	}
+}
+
+func TestRouteParamOfMountedRouter(t *testing.T) {
+	t.Parallel()
+	var route *Route
+
+	sub := New()
+	route = sub.AddGet("/members/:id", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		team, _ := params.Get("id")
+		member, _ := route.Param(params, "id")
+
+		w.Write([]byte(team + " " + member))
+	})
+
+	rtr := New()
+	rtr.Mount("/teams/:id", sub)
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/teams/1/members/2", nil)
+
+	rtr.ServeHTTP(w, r)
+
+	if body := w.Body.String(); body != "1 2" {
+		t.Errorf("Unexpected body %q", body)
+	}
+}
+
+func assertParam(t *testing.T, actual, expected interface{}, err error) {
+	t.Helper()
+
+	if err != nil || actual != expected {
+		t.Errorf("Expected %v but got %v, %v", expected, actual, err)
+	}
+}
@@ -0,0 +1,118 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// methods are the methods listed as allowed for patterns that do not name a
+// method and for mount points, as net/http.ServeMux matches those for every
+// method.
+var methods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// parsePattern translates a net/http.ServeMux pattern into the method it
+// names, if any, and a path in the router's own syntax:
+//
+//	GET /items/{id}     GET  /items/:id
+//	/files/{path...}         /files/*path
+//	/items/{$}               /items/
+//	/static/                 /static/*
+//
+// Wildcards may carry a regular expression the segment has to match, as in
+// "/items/{id:[0-9]+}", which becomes "/items/:id:[0-9]+".
+//
+// As in ServeMux, a trailing slash matches every path below it unless the
+// pattern ends in {$}. Paths without a method or {wildcard} segments are
+// taken as they are, so "/articles/" keeps matching "/articles/" only.
+func parsePattern(pattern string) (method, path string) {
+	path = pattern
+
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		method = pattern[:i]
+		path = strings.TrimLeft(pattern[i+1:], " \t")
+	} else if !hasWildcards(pattern) {
+		return "", pattern
+	}
+
+	if len(path) == 0 || path[0] != sepChar {
+		panic(fmt.Sprintf("Invalid Pattern: %s. Host patterns are not supported\n", pattern))
+	}
+
+	segments := strings.Split(path[1:], sep)
+
+	for i, segment := range segments {
+		last := i == len(segments)-1
+
+		switch {
+		case isParam(segment) || isCatchAll(segment):
+			// already in the router's own syntax
+
+		case segment == "{$}":
+			if !last {
+				panic(fmt.Sprintf("Invalid Pattern: %s. {$} must be at the end\n", pattern))
+			}
+			segments[i] = ""
+			return method, sep + strings.Join(segments, sep)
+
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "...}"):
+			if !last {
+				panic(fmt.Sprintf("Invalid Pattern: %s. {...} must be at the end\n", pattern))
+			}
+			segments[i] = string(catchAllSepChar) + wildcardName(pattern, segment[1:len(segment)-4])
+
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			segments[i] = string(pathParamSepChar) + wildcardName(pattern, segment[1:len(segment)-1])
+
+		case strings.ContainsAny(segment, "{}"):
+			panic(fmt.Sprintf("Invalid Pattern: %s. Wildcards must be full segments\n", pattern))
+
+		case last && segment == "":
+			// trailing slash matches everything below
+			segments[i] = string(catchAllSepChar)
+		}
+	}
+
+	return method, sep + strings.Join(segments, sep)
+}
+
+// ParsePattern translates pattern as Add does, into the method it names, if
+// any, and a path in the router's own syntax. It reports invalid patterns,
+// which Add panics on, as error.
+func ParsePattern(pattern string) (method, path string, err error) {
+	defer recoverError(&err)
+
+	method, path = parsePattern(pattern)
+
+	return method, path, nil
+}
+
+func wildcardName(pattern, name string) string {
+	if name == "" || name[0] == pathParamSepChar {
+		panic(fmt.Sprintf("Invalid Pattern: %s. Wildcards must be named\n", pattern))
+	}
+
+	return name
+}
+
+// hasWildcards reports whether path has segments in {wildcard} syntax.
+// Braces in the regular expression of a ":name:regexp" param do not count.
+func hasWildcards(path string) bool {
+	for _, segment := range strings.Split(path, sep) {
+		if strings.ContainsRune(segment, '{') && !isParam(segment) && !isCatchAll(segment) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,171 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParsePattern(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		pattern, method, path string
+	}{
+		{"/articles/", "", "/articles/"},
+		{"/pings/:id", "", "/pings/:id"},
+		{"GET /items/{id}", http.MethodGet, "/items/:id"},
+		{"POST  /items/{id}/tags/{tag}", http.MethodPost, "/items/:id/tags/:tag"},
+		{"/files/{path...}", "", "/files/*path"},
+		{"GET /items/{$}", http.MethodGet, "/items/"},
+		{"GET /{$}", http.MethodGet, "/"},
+		{"GET /static/", http.MethodGet, "/static/*"},
+		{"GET /", http.MethodGet, "/*"},
+		{"/items/{id:[0-9]+}", "", "/items/:id:[0-9]+"},
+		{"/years/{year:[0-9]{4}}", "", "/years/:year:[0-9]{4}"},
+		{"/years/:year:[0-9]{4}", "", "/years/:year:[0-9]{4}"},
+		{"GET /years/:year:[0-9]{4}/{month}", http.MethodGet, "/years/:year:[0-9]{4}/:month"},
+	} {
+		method, path := parsePattern(tc.pattern)
+		if method != tc.method || path != tc.path {
+			t.Errorf("\nPattern: %s\nExpected: %s %s\nActual:%s %s\n", tc.pattern, tc.method, tc.path, method, path)
+		}
+	}
+}
+
+func TestParseInvalidPattern(t *testing.T) {
+	t.Parallel()
+	for _, pattern := range []string{
+		"GET example.com/items",
+		"/files/{path...}/meta",
+		"/items/{$}/meta",
+		"/items/id-{id}",
+		"/items/{}",
+		"/items/{:[0-9]+}",
+	} {
+		t.Run(pattern, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic for %s", pattern)
+				}
+			}()
+
+			parsePattern(pattern)
+		})
+	}
+
+	t.Run("as error", func(t *testing.T) {
+		_, _, err := ParsePattern("/items/id-{id}")
+		if err == nil || err.Error() != "Invalid Pattern: /items/id-{id}. Wildcards must be full segments" {
+			t.Errorf("Unexpected error %v", err)
+		}
+	})
+}
+
+func TestRouteWithServeMuxPatterns(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.Add("GET /items/{id}", "", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte("item " + params.ByName("id")))
+	})
+
+	rtr.Add("GET /items/{$}", "", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte("items"))
+	})
+
+	rtr.Add("/files/{path...}", "", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte(r.Method + " file " + params.ByName("path")))
+	})
+
+	rtr.Add("GET /static/", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte("static " + r.URL.Path))
+	})
+
+	rtr.AddGet("/static/app.js", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte("app"))
+	})
+
+	for _, tc := range []struct {
+		method, path string
+		status       int
+		body         string
+	}{
+		{http.MethodGet, "/items/1", http.StatusOK, "item 1"},
+		{http.MethodGet, "/items/", http.StatusOK, "items"},
+		{http.MethodGet, "/items/1/tags", http.StatusNotFound, ""},
+		{http.MethodPost, "/items/1", http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "/files/a/b/c.txt", http.StatusOK, "GET file a/b/c.txt"},
+		{http.MethodDelete, "/files/a", http.StatusOK, "DELETE file a"},
+		{http.MethodGet, "/files/", http.StatusOK, "GET file "},
+		{http.MethodGet, "/static/app.js", http.StatusOK, "app"},
+		{http.MethodGet, "/static/app.js/map", http.StatusOK, "static /static/app.js/map"},
+		{http.MethodGet, "/static/css/site.css", http.StatusOK, "static /static/css/site.css"},
+	} {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(tc.method, tc.path, nil)
+
+			rtr.ServeHTTP(w, r)
+
+			assert.ResponseWithBody(t, w, tc.status, tc.body)
+		})
+	}
+}
+
+func TestRouteWithoutMethod(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.Add("/files/{path...}", "", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte(r.Method + " file " + params.ByName("path")))
+	})
+
+	rtr.AddPut("/files/readme", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte("readme"))
+	})
+
+	t.Run("serves methods the router does not know", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PURGE", "/files/a", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "PURGE file a")
+	})
+
+	t.Run("leaves the path to routes of the request method", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodPut, "/files/readme", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "readme")
+	})
+
+	t.Run("keeps the automatic OPTIONS response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodOptions, "/files/a", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNoContent)
+		assert.ResponseWithHeader(t, w, "Allow", "CONNECT, DELETE, GET, HEAD, OPTIONS, PATCH, POST, PUT, TRACE")
+	})
+
+	t.Run("is listed by Routes once", func(t *testing.T) {
+		if routes := rtr.Routes(); len(routes) != 2 || routes[0].Method != "" {
+			t.Errorf("Unexpected routes %v", routes)
+		}
+	})
+}
+
+func TestRouteWithConflictingMethod(t *testing.T) {
+	t.Parallel()
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic for conflicting methods")
+		}
+	}()
+
+	New().Add("GET /items/{id}", http.MethodPost, func(w http.ResponseWriter, r *http.Request, params PathParams) {})
+}
@@ -0,0 +1,277 @@
+package router
+
+import (
+	"encoding/json"
+	htmltemplate "html/template"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Problem describes an error response as RFC 7807 problem details.
+type Problem struct {
+	// Type is a URI identifying the kind of problem, "about:blank" if empty.
+	Type   string
+	Title  string
+	Status int
+	Detail string
+	// Instance is the request path.
+	Instance  string
+	Allowed   []string
+	RequestID string
+	// Extensions are added as members of the problem+json object.
+	Extensions map[string]interface{}
+}
+
+// MarshalJSON encodes p as problem+json object.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+7)
+
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+
+	members["type"] = p.Type
+	if p.Type == "" {
+		members["type"] = "about:blank"
+	}
+
+	members["title"] = p.Title
+	members["status"] = p.Status
+
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+	if len(p.Allowed) > 0 {
+		members["allowed"] = p.Allowed
+	}
+	if p.RequestID != "" {
+		members["requestId"] = p.RequestID
+	}
+
+	return json.Marshal(members)
+}
+
+// ErrorRenderer writes error responses as application/problem+json, HTML
+// page or plain text, whichever the Accept header of the request prefers.
+// Clients accepting any format get problem+json. Use Router.RenderErrors to
+// render the route errors, returned errors and panics of a Router.
+type ErrorRenderer struct {
+	// Problem customizes the problem details of a response, e.g. to set
+	// Type or Extensions.
+	Problem func(r *http.Request, p *Problem)
+
+	// HTML renders the Problem of text/html responses. If nil, a plain page
+	// is rendered.
+	HTML *htmltemplate.Template
+
+	// Text renders the Problem of text/plain responses. If nil, the status,
+	// detail, allowed methods and request ID are listed line by line.
+	Text *texttemplate.Template
+
+	// RequestIDHeader names the request or response header carrying the
+	// request ID, "X-Request-Id" if empty.
+	RequestIDHeader string
+}
+
+var defaultHTMLProblem = htmltemplate.Must(htmltemplate.New("problem").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Status}} {{.Title}}</title></head>
+<body>
+<h1>{{.Status}} {{.Title}}</h1>
+{{with .Detail}}<p>{{.}}</p>
+{{end}}{{with .Allowed}}<p>Allowed methods:{{range .}} {{.}}{{end}}</p>
+{{end}}{{with .RequestID}}<p>Request ID: <code>{{.}}</code></p>
+{{end}}</body>
+</html>
+`))
+
+var defaultTextProblem = texttemplate.Must(texttemplate.New("problem").Parse(`{{.Status}} {{.Title}}
+{{with .Detail}}{{.}}
+{{end}}{{with .Allowed}}Allowed methods:{{range .}} {{.}}{{end}}
+{{end}}{{with .RequestID}}Request ID: {{.}}
+{{end}}`))
+
+// RenderErrors lets er answer the unmatched requests, returned errors and
+// panics of rtr. Panics are logged as by DefaultPanicHandler.
+func (rtr *Router) RenderErrors(er *ErrorRenderer) {
+	rtr.NotFound = er
+	rtr.MethodNotAllowed = er
+	rtr.ErrorHandler = er.HandleError
+	rtr.PanicHandler = er.HandlePanic
+}
+
+// ServeHTTP renders the RouteError of a NotFound or MethodNotAllowed request,
+// or 404 Not Found if it has none.
+func (er *ErrorRenderer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, ok := RouteErrorFromContext(r.Context())
+	if !ok {
+		e = &RouteError{Status: http.StatusNotFound, Method: r.Method, Path: r.URL.Path}
+	}
+
+	er.Render(w, r, &Problem{
+		Status:  e.Status,
+		Detail:  e.Error(),
+		Allowed: e.Allowed,
+	})
+}
+
+// HandleError renders err as ErrorHandler, with the status and the message
+// DefaultErrorHandler would answer with.
+func (er *ErrorRenderer) HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	status, detail := errorResponse(r, err)
+
+	if status >= http.StatusInternalServerError {
+		// the title already is the status text
+		detail = ""
+	}
+
+	if headerWritten(w) {
+		return
+	}
+
+	er.Render(w, r, &Problem{Status: status, Detail: detail})
+}
+
+// HandlePanic logs the recovered value as PanicHandler and renders 500
+// Internal Server Error, unless the handler already sent the response
+// headers.
+func (er *ErrorRenderer) HandlePanic(w http.ResponseWriter, r *http.Request, recovered interface{}) {
+	logPanic(r, recovered)
+
+	if headerWritten(w) {
+		return
+	}
+
+	er.Render(w, r, &Problem{Status: http.StatusInternalServerError})
+}
+
+// Render completes p with the defaults of the request, lets Problem customize
+// it and writes it in the format the client prefers.
+func (er *ErrorRenderer) Render(w http.ResponseWriter, r *http.Request, p *Problem) {
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+
+	if p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+
+	if p.RequestID == "" {
+		header := er.RequestIDHeader
+		if header == "" {
+			header = "X-Request-Id"
+		}
+
+		p.RequestID = r.Header.Get(header)
+		if p.RequestID == "" {
+			p.RequestID = w.Header().Get(header)
+		}
+	}
+
+	if er.Problem != nil {
+		er.Problem(r, p)
+	}
+
+	if len(p.Allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(p.Allowed, ", "))
+	}
+
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	switch negotiate(r.Header.Get("Accept"), problemJSON, "text/html", "text/plain") {
+	case "text/html":
+		tmpl := er.HTML
+		if tmpl == nil {
+			tmpl = defaultHTMLProblem
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(p.Status)
+		tmpl.Execute(w, p)
+
+	case "text/plain":
+		tmpl := er.Text
+		if tmpl == nil {
+			tmpl = defaultTextProblem
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(p.Status)
+		tmpl.Execute(w, p)
+
+	default:
+		body, _ := json.Marshal(p)
+
+		w.Header().Set("Content-Type", problemJSON)
+		w.WriteHeader(p.Status)
+		w.Write(append(body, '\n'))
+	}
+}
+
+const problemJSON = "application/problem+json"
+
+// negotiate returns the offer the Accept header prefers, the first offer on
+// a tie or if the header is empty or accepts none of them. JSON media ranges
+// accept problem+json. The quality of an offer is the one of the most
+// specific media range matching it, and offers of quality 0 are excluded.
+func negotiate(accept string, offers ...string) string {
+	best, bestQ := offers[0], 0.0
+
+	if strings.TrimSpace(accept) == "" {
+		return best
+	}
+
+	for _, offer := range offers {
+		q, specificity := 0.0, -1
+
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil {
+				continue
+			}
+
+			rangeSpecificity := specificityOf(mediaType, offer)
+			if rangeSpecificity <= specificity {
+				continue
+			}
+
+			rangeQ := 1.0
+			if value, ok := params["q"]; ok {
+				if rangeQ, err = strconv.ParseFloat(value, 64); err != nil {
+					continue
+				}
+			}
+
+			q, specificity = rangeQ, rangeSpecificity
+		}
+
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+
+	return best
+}
+
+// specificityOf ranks how closely mediaRange matches offer, from */* up to
+// the offer itself, or returns -1 if it does not match.
+func specificityOf(mediaRange, offer string) int {
+	switch {
+	case mediaRange == offer:
+		return 3
+	case offer == problemJSON && mediaRange == "application/json":
+		return 2
+	case mediaRange == "*/*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(offer, strings.TrimSuffix(mediaRange, "*")):
+		return 1
+	default:
+		return -1
+	}
+}
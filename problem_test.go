@@ -0,0 +1,117 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"text/template"
+)
+
+func TestRouteWithErrorRenderer(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.RenderErrors(&ErrorRenderer{
+		Problem: func(r *http.Request, p *Problem) {
+			if p.Status == http.StatusConflict {
+				p.Type = "https://example.com/problems/conflict"
+				p.Extensions = map[string]interface{}{"retry": false}
+			}
+		},
+		Text: template.Must(template.New("text").Parse("{{.Status}}: {{.Detail}}\n")),
+	})
+
+	rtr.AddPost("/pings", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write(pong)
+	})
+
+	rtr.AddWithError("/pings/:id", http.MethodPut, func(w http.ResponseWriter, r *http.Request, params PathParams) error {
+		return ErrorWithStatus(http.StatusConflict, errors.New("ping was changed"))
+	})
+
+	rtr.AddGet("/panic", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		panic("boom")
+	})
+
+	t.Run("renders problem details by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings", nil)
+		r.Header.Set("X-Request-Id", "req-1")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusMethodNotAllowed, `{"allowed":["OPTIONS","POST"],"detail":"GET /pings: method not allowed",`+
+			`"instance":"/pings","requestId":"req-1","status":405,"title":"Method Not Allowed","type":"about:blank"}`+"\n")
+		assert.ResponseWithHeader(t, w, "Content-Type", "application/problem+json")
+		assert.ResponseWithHeader(t, w, "Allow", "OPTIONS, POST")
+	})
+
+	t.Run("renders customized problem details", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodPut, "/pings/1", nil)
+		r.Header.Set("Accept", "application/json")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusConflict, `{"detail":"ping was changed","instance":"/pings/1","retry":false,`+
+			`"status":409,"title":"Conflict","type":"https://example.com/problems/conflict"}`+"\n")
+	})
+
+	t.Run("renders HTML pages for browsers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/unknown/<script>", nil)
+		r.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>404 Not Found</title></head>
+<body>
+<h1>404 Not Found</h1>
+<p>GET /unknown/&lt;script&gt;: not found</p>
+</body>
+</html>
+`)
+		assert.ResponseWithHeader(t, w, "Content-Type", "text/html; charset=utf-8")
+	})
+
+	t.Run("renders customized plain text", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/unknown", nil)
+		r.Header.Set("Accept", "text/plain, application/json;q=0.5")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, "404: GET /unknown: not found\n")
+	})
+
+	t.Run("takes the quality of the most specific media range", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/unknown", nil)
+		r.Header.Set("Accept", "text/html;q=0, text/*, */*;q=0.8")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, "404: GET /unknown: not found\n")
+	})
+
+	t.Run("renders panics without details", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/panic", nil)
+		r.Header.Set("Accept", "text/*")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusInternalServerError, `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>500 Internal Server Error</title></head>
+<body>
+<h1>500 Internal Server Error</h1>
+</body>
+</html>
+`)
+	})
+}
@@ -0,0 +1,316 @@
+package router
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// headResponseWriter serves HEAD requests with GET handlers. It discards the
+// body but counts it, so the response reports the Content-Length the GET
+// response would have had.
+type headResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	written     int64
+	wroteHeader bool
+}
+
+func (w *headResponseWriter) WriteHeader(status int) {
+	// informational responses are sent as they come
+	if status >= 100 && status <= 199 {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *headResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	w.written += int64(len(b))
+
+	return len(b), nil
+}
+
+// Flush sends the headers without a Content-Length as the body length is
+// not known yet.
+func (w *headResponseWriter) Flush() {
+	w.writeHeader()
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer.
+func (w *headResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *headResponseWriter) headerSent() bool {
+	return w.wroteHeader
+}
+
+// finish reports the counted body length and sends the deferred status.
+func (w *headResponseWriter) finish() {
+	if w.wroteHeader {
+		return
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	header := w.Header()
+	if bodyAllowed(w.status) && header.Get("Content-Length") == "" && header.Get("Transfer-Encoding") == "" {
+		header.Set("Content-Length", strconv.FormatInt(w.written, 10))
+	}
+
+	w.writeHeader()
+}
+
+func (w *headResponseWriter) writeHeader() {
+	if w.wroteHeader {
+		return
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// statusWriter records whether the response headers were sent, so a panic
+// can still be answered with an error status when nothing was written yet.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+
+	// catching writers take the error of a JSON handler, see failJSON
+	catching bool
+	err      error
+
+	// wrappers adding the optional interfaces of the underlying writer,
+	// kept with the pooled writer so wrap does not allocate
+	f    flushWriter
+	h    hijackWriter
+	rf   readFromWriter
+	fh   flushHijackWriter
+	frf  flushReadFromWriter
+	hrf  hijackReadFromWriter
+	fhrf flushHijackReadFromWriter
+}
+
+type (
+	flushWriter struct {
+		*statusWriter
+		flusher
+	}
+	hijackWriter struct {
+		*statusWriter
+		hijacker
+	}
+	readFromWriter struct {
+		*statusWriter
+		readerFrom
+	}
+	flushHijackWriter struct {
+		*statusWriter
+		flusher
+		hijacker
+	}
+	flushReadFromWriter struct {
+		*statusWriter
+		flusher
+		readerFrom
+	}
+	hijackReadFromWriter struct {
+		*statusWriter
+		hijacker
+		readerFrom
+	}
+	flushHijackReadFromWriter struct {
+		*statusWriter
+		flusher
+		hijacker
+		readerFrom
+	}
+)
+
+var statusWriterPool = sync.Pool{
+	New: func() interface{} {
+		w := new(statusWriter)
+		f, h, rf := flusher{w}, hijacker{w}, readerFrom{w}
+
+		w.f = flushWriter{w, f}
+		w.h = hijackWriter{w, h}
+		w.rf = readFromWriter{w, rf}
+		w.fh = flushHijackWriter{w, f, h}
+		w.frf = flushReadFromWriter{w, f, rf}
+		w.hrf = hijackReadFromWriter{w, h, rf}
+		w.fhrf = flushHijackReadFromWriter{w, f, h, rf}
+
+		return w
+	},
+}
+
+func newStatusWriter(w http.ResponseWriter) *statusWriter {
+	sw := statusWriterPool.Get().(*statusWriter)
+	sw.ResponseWriter = w
+	sw.status = 0
+	sw.catching = false
+	sw.err = nil
+
+	return sw
+}
+
+func releaseStatusWriter(sw *statusWriter) {
+	sw.ResponseWriter = nil
+	sw.err = nil
+	statusWriterPool.Put(sw)
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	// informational responses do not count as the final header
+	if w.status == 0 && (status < 100 || status > 199) {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *statusWriter) headerSent() bool {
+	return w.status != 0
+}
+
+func (w *statusWriter) catch(err error) bool {
+	if w.catching {
+		w.err = err
+	}
+
+	return w.catching
+}
+
+// wrap returns w with the optional interfaces of the underlying writer, so
+// handlers see http.Flusher, http.Hijacker and io.ReaderFrom only if the
+// connection supports them.
+func (w *statusWriter) wrap() http.ResponseWriter {
+	_, f := w.ResponseWriter.(http.Flusher)
+	_, h := w.ResponseWriter.(http.Hijacker)
+	_, rf := w.ResponseWriter.(io.ReaderFrom)
+
+	switch {
+	case f && h && rf:
+		return &w.fhrf
+	case f && h:
+		return &w.fh
+	case f && rf:
+		return &w.frf
+	case h && rf:
+		return &w.hrf
+	case f:
+		return &w.f
+	case h:
+		return &w.h
+	case rf:
+		return &w.rf
+	}
+
+	return w
+}
+
+type flusher struct{ *statusWriter }
+
+func (w flusher) Flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	w.ResponseWriter.(http.Flusher).Flush()
+}
+
+type hijacker struct{ *statusWriter }
+
+func (w hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := w.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil {
+		w.status = http.StatusSwitchingProtocols
+	}
+
+	return conn, rw, err
+}
+
+type readerFrom struct{ *statusWriter }
+
+func (w readerFrom) ReadFrom(r io.Reader) (int64, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	return w.ResponseWriter.(io.ReaderFrom).ReadFrom(r)
+}
+
+// tracksHeader reports whether a writer wrapped by the router records if the
+// response headers of w were sent.
+func tracksHeader(w http.ResponseWriter) bool {
+	for {
+		switch w.(type) {
+		case *headResponseWriter:
+		case interface{ headerSent() bool }:
+			return true
+		}
+
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return false
+		}
+
+		w = unwrapper.Unwrap()
+	}
+}
+
+// headerWritten reports whether the response headers of w were already sent,
+// as far as the writers wrapped by the router know. Writers are unwrapped
+// as by http.ResponseController.
+func headerWritten(w http.ResponseWriter) bool {
+	for {
+		if tracker, ok := w.(interface{ headerSent() bool }); ok && tracker.headerSent() {
+			return true
+		}
+
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return false
+		}
+
+		w = unwrapper.Unwrap()
+	}
+}
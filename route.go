@@ -1,41 +1,344 @@
 package router
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
+	"sort"
+	"strings"
 )
 
 type HandlerFuncWithParam func(w http.ResponseWriter, request *http.Request, param PathParams)
 
 type Router struct {
-	routes map[string]*node
+	routes     map[string]*node
+	routeList  []*Route
+	middleware []Middleware
+	scopes     []*errorScope
+	mounted    bool
+
+	// HandleOPTIONS enables automatic replies to OPTIONS requests for paths
+	// that have no OPTIONS route of their own. The reply carries an Allow
+	// header listing the methods registered for the path.
+	HandleOPTIONS bool
+
+	// GlobalOPTIONS is called for automatic OPTIONS replies instead of the
+	// default 204 No Content, e.g. to answer CORS preflight requests. The
+	// Allow header is already set when it is called.
+	GlobalOPTIONS http.Handler
+
+	// HandleHEAD dispatches HEAD requests to the GET handler of a path that
+	// has no HEAD route of its own. The response body is discarded while its
+	// Content-Length is still reported.
+	HandleHEAD bool
+
+	// NotFound is called when no route matches the request path. The
+	// RouteError is available through RouteErrorFromContext. If nil, a bare
+	// 404 is written.
+	NotFound http.Handler
+
+	// MethodNotAllowed is called when the request path matches routes of
+	// other methods only. The Allow header is already set and the
+	// RouteError is available through RouteErrorFromContext. If nil, a bare
+	// 405 is written.
+	MethodNotAllowed http.Handler
+
+	// ErrorHandler answers the errors returned by HandlerFuncWithError and
+	// JSON routes outside of groups with an ErrorHandler of their own. If
+	// nil, DefaultErrorHandler is used, and JSON routes answer with a JSON
+	// body.
+	ErrorHandler func(w http.ResponseWriter, request *http.Request, err error)
+
+	// PanicHandler is called with the recovered value when a handler
+	// panics. If nil, panics are not recovered.
+	PanicHandler func(w http.ResponseWriter, request *http.Request, recovered interface{})
 }
 
 func New() *Router {
-	return &Router{}
+	return &Router{
+		HandleOPTIONS: true,
+		HandleHEAD:    true,
+		PanicHandler:  DefaultPanicHandler,
+	}
 }
 
 func (rtr *Router) ServeHTTP(w http.ResponseWriter, request *http.Request) {
 	path := request.URL.Path
 	method := request.Method
 
-	routes := rtr.routes[method]
+	if rtr.PanicHandler != nil {
+		sw := newStatusWriter(w)
+		defer rtr.recover(sw, request)
+		w = sw.wrap()
+	}
 
-	if routes == nil {
-		handleError(rtr, w, path, method)
-		return
+	if routes := rtr.routes[method]; routes != nil {
+		if handle, params := routes.findRoute(path); handle != nil {
+			if rtr.mounted {
+				params = mountedParams(request, params)
+			}
+
+			handle(w, request, params)
+			return
+		}
 	}
 
-	handle, params := routes.findRoute(path)
+	if method == http.MethodHead && rtr.HandleHEAD {
+		if routes := rtr.routes[http.MethodGet]; routes != nil {
+			if handle, params := routes.findRoute(path); handle != nil {
+				if rtr.mounted {
+					params = mountedParams(request, params)
+				}
 
-	if handle == nil {
-		handleError(rtr, w, path, method)
-		return
+				hw := &headResponseWriter{ResponseWriter: w}
+				handle(hw, request, params)
+				hw.finish()
+				return
+			}
+		}
+	}
+
+	// mount points answer OPTIONS requests themselves
+	if method == http.MethodOptions && rtr.HandleOPTIONS && !rtr.mounts(path) {
+		if allow := rtr.allowed(path, method); len(allow) > 0 {
+			w.Header().Set("Allow", strings.Join(allow, ", "))
+
+			if rtr.GlobalOPTIONS != nil {
+				rtr.GlobalOPTIONS.ServeHTTP(w, request)
+			} else {
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		}
+	}
+
+	// routes of patterns without method serve all others
+	if routes := rtr.routes[""]; routes != nil {
+		if handle, params := routes.findRoute(path); handle != nil {
+			if rtr.mounted {
+				params = mountedParams(request, params)
+			}
+
+			handle(w, request, params)
+			return
+		}
+	}
+
+	handleError(rtr, w, request)
+}
+
+// Route describes a registered route.
+type Route struct {
+	// Method of the route, empty if the route serves all methods.
+	Method string
+
+	// Path of the route in the router's own syntax, e.g. "/items/:id".
+	Path string
+
+	// Meta describes the route, e.g. for documentation.
+	Meta Meta
+
+	handler    HandlerFuncWithParam
+	middleware []Middleware
+	group      *Group
+	nodes      []*node
+	mount      bool
+	index      map[string]int
+	numParams  int
+}
+
+// Meta holds descriptive data of a route.
+type Meta map[string]interface{}
+
+// Set stores a Meta value of the route.
+func (route *Route) Set(key string, value interface{}) *Route {
+	if route.Meta == nil {
+		route.Meta = Meta{}
+	}
+
+	route.Meta[key] = value
+
+	return route
+}
+
+// Param returns the value of the param name from the params of a request
+// served by route, by the position of the param in the path of the route.
+// It serves middleware, which receive their route. The params of a mounted
+// Router start with those of its mount point, which Param skips: where both
+// have a param of the same name, PathParams.Get returns the one of the mount
+// point and Param the one of route.
+func (route *Route) Param(params PathParams, name string) (string, bool) {
+	i, ok := route.index[name]
+
+	// a mounted Router puts the params of its mount point in front
+	offset := len(params) - route.numParams
+
+	if !ok || offset < 0 {
+		return "", false
 	}
 
-	handle(w, request, params)
+	return params[offset+i].Value, true
+}
+
+// indexParams records the position of each param in the path of route.
+func (route *Route) indexParams() {
+	route.index = make(map[string]int)
+	route.numParams = 0
+
+	for _, p := range route.Params() {
+		if _, ok := route.index[p.Name]; !ok {
+			route.index[p.Name] = route.numParams
+		}
+		route.numParams++
+	}
 }
 
-func (rtr *Router) Add(path string, method string, handler HandlerFuncWithParam) {
+// RouteParam describes a path param of a route.
+type RouteParam struct {
+	Name string
+
+	// Constraint is the regular expression the param has to match, if any.
+	Constraint string
+
+	// CatchAll is set for params matching the rest of the path.
+	CatchAll bool
+}
+
+// Params returns the named path params of the route in the order of its
+// path. Anonymous catch-alls, e.g. of mount points, are left out.
+func (route *Route) Params() []RouteParam {
+	var params []RouteParam
+
+	for _, segment := range strings.Split(route.Path, sep) {
+		key, constraint := splitParam(segment)
+		if key == "" {
+			continue
+		}
+
+		params = append(params, RouteParam{
+			Name:       key,
+			Constraint: constraint,
+			CatchAll:   isCatchAll(segment),
+		})
+	}
+
+	return params
+}
+
+// Routes returns the registered routes in the order they were added. Routes
+// replaced by a later registration of the same method and path are left out.
+func (rtr *Router) Routes() []*Route {
+	routes := make([]*Route, 0, len(rtr.routeList))
+
+	for _, route := range rtr.routeList {
+		if len(route.nodes) > 0 {
+			routes = append(routes, route)
+		}
+	}
+
+	return routes
+}
+
+// Add registers a new request handle with the given path and method, wrapped
+// by the given middleware. The path may also be a net/http.ServeMux pattern
+// such as "GET /items/{id}", in which case method may be left empty.
+// Patterns without any method serve all methods, after the routes of the
+// request method and the automatic OPTIONS response. Add panics on invalid
+// paths, see Check.
+func (rtr *Router) Add(path string, method string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	route := rtr.add(path, method, handler, middleware)
+	rtr.compose(route)
+
+	return route
+}
+
+// Check reports the invalid path or method Add panics on as error, without
+// registering a route. Registering a path and method again is no error, the
+// new route replaces the previous one.
+func (rtr *Router) Check(path string, method string) (err error) {
+	defer recoverError(&err)
+
+	parseRoute(path, method)
+
+	return nil
+}
+
+// recoverError turns the panic of an invalid route into an error.
+func recoverError(err *error) {
+	if recovered := recover(); recovered != nil {
+		*err = errors.New(strings.TrimSpace(fmt.Sprint(recovered)))
+	}
+}
+
+// parseRoute returns the method and path of a route registered with path and
+// method, and panics if they are invalid.
+func parseRoute(path string, method string) (string, string) {
+	patternMethod, routePath := parsePattern(path)
+
+	switch {
+	case method == "":
+		method = patternMethod
+	case patternMethod != "" && patternMethod != method:
+		panic(fmt.Sprintf("Invalid Pattern: %s. Method conflicts with %s\n", path, method))
+	}
+
+	if len(routePath) == 0 || routePath[0] != sepChar {
+		panic(fmt.Sprintf("Invalid Path: %s. Path must begin with '/'\n", routePath))
+	}
+
+	if isIndex(routePath) {
+		return method, routePath
+	}
+
+	parts := strings.Split(routePath[1:], sep)
+
+	for i, part := range parts {
+		if isCatchAll(part) && i < len(parts)-1 {
+			panic(fmt.Sprintf("Invalid Path: %s. Catch-all must be the last segment\n", routePath[1:]))
+		}
+
+		if _, constraint := splitParam(part); constraint != "" {
+			if _, err := regexp.Compile("^(?:" + constraint + ")$"); err != nil {
+				panic(fmt.Sprintf("Invalid Path Param: %s. %v\n", part, err))
+			}
+		}
+	}
+
+	return method, routePath
+}
+
+// add registers route without composing its handler chain.
+func (rtr *Router) add(path string, method string, handler HandlerFuncWithParam, middleware []Middleware) *Route {
+	method, routePath := parseRoute(path, method)
+
+	route := &Route{
+		Method:     method,
+		Path:       routePath,
+		handler:    handler,
+		middleware: middleware,
+	}
+	route.indexParams()
+
+	if handler != nil && isJSONHandler(handler) {
+		route.handler = handleJSONErrors(rtr, route, handler)
+	}
+
+	rtr.insert(route, method)
+
+	rtr.routeList = append(rtr.routeList, route)
+
+	return route
+}
+
+func (rtr *Router) insert(route *Route, method string) {
+	rtr.insertPath(route, method, route.Path)
+}
+
+// insertPath installs route for method at path, which may differ from the
+// path of the route, see Mount.
+func (rtr *Router) insertPath(route *Route, method, path string) {
 	if rtr.routes == nil {
 		rtr.routes = make(map[string]*node)
 	}
@@ -47,64 +350,177 @@ func (rtr *Router) Add(path string, method string, handler HandlerFuncWithParam)
 		rtr.routes[method] = root
 	}
 
-	root.addRoute(path, handler)
+	leaf := root.addRoute(path, route.handler)
+
+	// a route registered again replaces the previous one
+	if previous := leaf.route; previous != nil && previous != route {
+		previous.detach(leaf)
+	}
+
+	leaf.route = route
+	route.nodes = append(route.nodes, leaf)
 }
 
-// AddGet registers a new request handle with the given path and Get-method.
-func (rtr *Router) AddGet(path string, handler HandlerFuncWithParam) {
-	rtr.Add(path, http.MethodGet, handler)
+func (route *Route) detach(leaf *node) {
+	for i, n := range route.nodes {
+		if n == leaf {
+			route.nodes = append(route.nodes[:i], route.nodes[i+1:]...)
+			return
+		}
+	}
+}
+
+// Handle registers a http.Handler with the given method and path, wrapped by
+// the given middleware. The path params of the route are available through
+// ParamsFromContext and http.Request.PathValue.
+func (rtr *Router) Handle(method, path string, handler http.Handler, middleware ...Middleware) *Route {
+	return rtr.Add(path, method, serveHandler(handler), middleware...)
+}
+
+func serveHandler(handler http.Handler) HandlerFuncWithParam {
+	return func(w http.ResponseWriter, request *http.Request, params PathParams) {
+		if len(params) > 0 {
+			request = withParams(request, params)
+		}
+
+		handler.ServeHTTP(w, request)
+	}
+}
 
+// HandleFunc registers a http.HandlerFunc with the given method and path.
+func (rtr *Router) HandleFunc(method, path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return rtr.Handle(method, path, handler, middleware...)
+}
+
+// AddGet registers a new request handle with the given path and Get-method.
+func (rtr *Router) AddGet(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return rtr.Add(path, http.MethodGet, handler, middleware...)
 }
 
 // AddPost registers a new request handle with the given path and Post-method.
-func (rtr *Router) AddPost(path string, handler HandlerFuncWithParam) {
-	rtr.Add(path, http.MethodPost, handler)
+func (rtr *Router) AddPost(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return rtr.Add(path, http.MethodPost, handler, middleware...)
 }
 
 // AddPut registers a new request handle with the given path and Put-method.
-func (rtr *Router) AddPut(path string, handler HandlerFuncWithParam) {
-	rtr.Add(path, http.MethodPut, handler)
+func (rtr *Router) AddPut(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return rtr.Add(path, http.MethodPut, handler, middleware...)
 }
 
 // AddDelete registers a new request handle with the given path and Delete-method.
-func (rtr *Router) AddDelete(path string, handler HandlerFuncWithParam) {
-	rtr.Add(path, http.MethodDelete, handler)
+func (rtr *Router) AddDelete(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return rtr.Add(path, http.MethodDelete, handler, middleware...)
 }
 
 // AddOptions registers a new request handle with the given path and Options-method.
-func (rtr *Router) AddOptions(path string, handler HandlerFuncWithParam) {
-	rtr.Add(path, http.MethodOptions, handler)
+func (rtr *Router) AddOptions(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return rtr.Add(path, http.MethodOptions, handler, middleware...)
 }
 
 // AddPatch registers a new request handle with the given path and Patch-method.
-func (rtr *Router) AddPatch(path string, handler HandlerFuncWithParam) {
-	rtr.Add(path, http.MethodPatch, handler)
+func (rtr *Router) AddPatch(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return rtr.Add(path, http.MethodPatch, handler, middleware...)
 }
 
 // AddHead registers a new request handle with the given path and Head-method.
-func (rtr *Router) AddHead(path string, handler HandlerFuncWithParam) {
-	rtr.Add(path, http.MethodHead, handler)
+func (rtr *Router) AddHead(path string, handler HandlerFuncWithParam, middleware ...Middleware) *Route {
+	return rtr.Add(path, http.MethodHead, handler, middleware...)
+}
+
+func handleError(router *Router, writer http.ResponseWriter, request *http.Request) {
+	e := &RouteError{
+		Status: http.StatusNotFound,
+		Method: request.Method,
+		Path:   request.URL.Path,
+	}
+
+	if allow := router.allowed(e.Path, e.Method); len(allow) > 0 {
+		writer.Header().Set("Allow", strings.Join(allow, ", "))
+		e.Status = http.StatusMethodNotAllowed
+		e.Allowed = allow
+	}
+
+	handler := router.errorHandler(e)
+
+	if handler == nil && router.mounted {
+		handler, e = mountedErrorHandler(request, e)
+	}
+
+	if handler == nil {
+		writer.WriteHeader(e.Status)
+		return
+	}
+
+	handler.ServeHTTP(writer, request.WithContext(context.WithValue(request.Context(), routeErrorKey{}, e)))
+}
+
+// mounts reports whether path is served by a mount point.
+func (rtr *Router) mounts(path string) bool {
+	root := rtr.routes[""]
+	if root == nil {
+		return false
+	}
+
+	n, _ := root.lookup(path)
+
+	return n != nil && n.route != nil && n.route.mount
 }
 
-func handleError(router *Router, writer http.ResponseWriter, path, requestMethod string) {
-	status := http.StatusNotFound
+// allowed returns the sorted list of methods that have a route for path.
+// The server-wide path "*" allows every registered method. OPTIONS and HEAD
+// are included whenever the router answers them on behalf of other routes.
+func (rtr *Router) allowed(path, requestMethod string) []string {
+	var allow []string
 
-search:
-	for method := range router.routes {
+	for method, root := range rtr.routes {
 		// skip search as we know request method is already searched by normal flow
-		if method == requestMethod {
-			continue search
+		if method == requestMethod || method == "" {
+			continue
+		}
+
+		if path == "*" {
+			allow = append(allow, method)
+			continue
+		}
+
+		if handle, _ := root.findRoute(path); handle != nil {
+			allow = append(allow, method)
+		}
+	}
+
+	if root := rtr.routes[""]; root != nil {
+		if handle, _ := root.findRoute(path); handle != nil || path == "*" {
+			for _, method := range methods {
+				if method != requestMethod && !contains(allow, method) {
+					allow = append(allow, method)
+				}
+			}
 		}
+	}
 
-		root := router.routes[method]
+	if len(allow) == 0 {
+		return nil
+	}
+
+	if rtr.HandleOPTIONS && !contains(allow, http.MethodOptions) {
+		allow = append(allow, http.MethodOptions)
+	}
 
-		handle, _ := root.findRoute(path)
+	if rtr.HandleHEAD && contains(allow, http.MethodGet) && !contains(allow, http.MethodHead) {
+		allow = append(allow, http.MethodHead)
+	}
+
+	sort.Strings(allow)
+
+	return allow
+}
 
-		if handle != nil {
-			status = http.StatusMethodNotAllowed
-			break search
+func contains(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
 		}
 	}
 
-	writer.WriteHeader(status)
+	return false
 }
@@ -187,6 +187,113 @@ func TestRouteWithPathParams(t *testing.T) {
 		assert.ResponseWithBody(t, w, http.StatusOK, "4s")
 	})
 
+	t.Run("returns 200 for a route with constrained path params", func(t *testing.T) {
+		rtr := New()
+		rtr.Add("/pings/:id:[0-9]+", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write([]byte("number " + params.ByName("id")))
+		})
+		rtr.Add("/pings/:name:[a-z]+", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write([]byte("name " + params.ByName("name")))
+		})
+		rtr.Add("/pings/:any", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write([]byte("any " + params.ByName("any")))
+		})
+
+		for path, body := range map[string]string{
+			"/pings/42":   "number 42",
+			"/pings/pong": "name pong",
+			"/pings/P0ng": "any P0ng",
+		} {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodGet, path, nil)
+
+			rtr.ServeHTTP(w, r)
+
+			assert.ResponseWithBody(t, w, http.StatusOK, body)
+		}
+	})
+
+	t.Run("returns 404 for a route with unmatched constraint", func(t *testing.T) {
+		rtr := New()
+		rtr.Add("/pings/:id:[0-9]+", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write(pong)
+		})
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/pings/pong", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNotFound)
+	})
+
+	t.Run("returns 200 for a param route when a static segment leads nowhere", func(t *testing.T) {
+		rtr := New()
+		rtr.Add("/repos/new/settings", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write([]byte("settings"))
+		})
+		rtr.Add("/repos/:owner/pulls", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write([]byte("pulls of " + params.ByName("owner")))
+		})
+		rtr.Add("/repos/*rest", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write([]byte("rest " + params.ByName("rest")))
+		})
+
+		for path, body := range map[string]string{
+			"/repos/new/settings": "settings",
+			"/repos/new/pulls":    "pulls of new",
+			"/repos/new/issues":   "rest new/issues",
+		} {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodGet, path, nil)
+
+			rtr.ServeHTTP(w, r)
+
+			assert.ResponseWithBody(t, w, http.StatusOK, body)
+		}
+	})
+
+	t.Run("returns 200 for routes with differently named path params", func(t *testing.T) {
+		rtr := New()
+		rtr.Add("/users/:id/posts", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write([]byte("posts of " + params.ByName("id")))
+		})
+		rtr.Add("/users/:name/likes", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write([]byte("likes of " + params.ByName("name")))
+		})
+		rtr.Add("/users/:id:[0-9]+", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write([]byte("user " + params.ByName("id")))
+		})
+
+		for path, body := range map[string]string{
+			"/users/1/posts":  "posts of 1",
+			"/users/1/likes":  "likes of 1",
+			"/users/go/likes": "likes of go",
+			"/users/1":        "user 1",
+		} {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodGet, path, nil)
+
+			rtr.ServeHTTP(w, r)
+
+			assert.ResponseWithBody(t, w, http.StatusOK, body)
+		}
+	})
+
+	t.Run("returns 200 for a route with catch-all", func(t *testing.T) {
+		rtr := New()
+		rtr.Add("/src/:version/*filepath", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
+			w.Write([]byte(params.ByName("version") + ":" + params.ByName("filepath")))
+		})
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/src/v1/cmd/main.go", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "v1:cmd/main.go")
+	})
+
 	t.Run("returns 404 for not found path with similar base paths", func(t *testing.T) {
 		rtr := New()
 		rtr.Add("/ping", http.MethodGet, func(w http.ResponseWriter, r *http.Request, params PathParams) {
@@ -290,6 +397,53 @@ func TestRouteWithUncleanPath(t *testing.T) {
 	})
 }
 
+func TestRouteWithInvalidPaths(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		path, method, expected string
+	}{
+		{"pings", http.MethodGet, "Invalid Path: pings. Path must begin with '/'"},
+		{"/files/*path/raw", http.MethodGet, "Invalid Path: files/*path/raw. Catch-all must be the last segment"},
+		{"/items/:id:[0-9", http.MethodGet, "Invalid Path Param: :id:[0-9. error parsing regexp: missing closing ]: `[0-9)$`"},
+		{"POST /items", http.MethodGet, "Invalid Pattern: POST /items. Method conflicts with GET"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			rtr := New()
+
+			if err := rtr.Check(tc.path, tc.method); err == nil || err.Error() != tc.expected {
+				t.Errorf("\nExpected: %s\nActual:   %v", tc.expected, err)
+			}
+
+			if len(rtr.Routes()) != 0 {
+				t.Errorf("Expected no routes, got %v", rtr.Routes())
+			}
+
+			defer func() {
+				if recovered := recover(); recovered == nil || fmt.Sprint(recovered) != tc.expected+"\n" {
+					t.Errorf("\nExpected panic: %s\nActual:         %v", tc.expected, recovered)
+				}
+			}()
+
+			rtr.Add(tc.path, tc.method, func(w http.ResponseWriter, r *http.Request, params PathParams) {})
+		})
+	}
+
+	t.Run("below the prefix of a group", func(t *testing.T) {
+		group := New().Group("/files/*path")
+
+		if err := group.Check("/raw", http.MethodGet); err == nil {
+			t.Error("Expected an error for a route below a catch-all")
+		}
+	})
+
+	t.Run("accepts valid paths", func(t *testing.T) {
+		if err := New().Check("GET /items/{id}", ""); err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+	})
+}
+
 func TestRouter_ServeHTTP(t *testing.T) {
 	rtr := New()
 	for _, route := range fixture.Routes {
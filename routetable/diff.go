@@ -0,0 +1,391 @@
+package routetable
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ChangeKind classifies a Change.
+type ChangeKind string
+
+const (
+	// Added routes serve requests that were not found before.
+	Added ChangeKind = "added"
+
+	// Removed routes no longer serve their requests.
+	Removed ChangeKind = "removed"
+
+	// MethodChanged routes serve their path for another method.
+	MethodChanged ChangeKind = "method changed"
+
+	// ParamsRenamed routes name their path params differently, their
+	// requests are not affected but handlers and generated code are.
+	ParamsRenamed ChangeKind = "params renamed"
+
+	// ConstraintChanged routes constrain their path params differently.
+	ConstraintChanged ChangeKind = "constraint changed"
+
+	// Shadowed routes lose some of their requests to a new route matched
+	// first, see router.Router.Add.
+	Shadowed ChangeKind = "shadowed"
+)
+
+// Change is a difference between two route tables.
+type Change struct {
+	Kind ChangeKind
+
+	// Old is the route before the change, New the route after it. Old is
+	// empty for added routes, New for removed ones. Of shadowed routes, Old
+	// is the shadowed route and New the route shadowing it.
+	Old, New Route
+
+	// Breaking changes turn requests that were served before into errors or
+	// send them to other handlers.
+	Breaking bool
+
+	// Detail describes the change, e.g. ":id is now :item".
+	Detail string
+}
+
+func (change Change) String() string {
+	route := change.Old
+	if change.Kind == Added {
+		route = change.New
+	}
+
+	s := string(change.Kind) + " " + route.String()
+	if change.Detail != "" {
+		s += ": " + change.Detail
+	}
+
+	return s
+}
+
+// Diff compares two versions of a route table. Routes are the same if their
+// methods and paths are, regardless of the names of their params. A route
+// whose params are constrained differently replaces the old one, unless that
+// is still in the new table, e.g. /items/:slug next to /items/:id:[0-9]+.
+// It reports
+//
+//   - removed routes and routes serving another method, both breaking
+//     unless a route for all methods still serves the path
+//   - added routes, not breaking
+//   - renamed params, not breaking
+//   - constraints added to or changed of params, breaking, and constraints
+//     dropped, not breaking
+//   - routes of both tables losing requests to an added route, breaking
+//
+// Changes are listed in the order of the old table, followed by added and
+// shadowed routes in the order of the new one.
+func Diff(old, new []Route) []Change {
+	var (
+		changes  []Change
+		oldIndex = indexRoutes(old)
+		newIndex = indexRoutes(new)
+		removed  []Route
+		added    []Route
+		paired   = map[int]bool{}
+	)
+
+	for i, route := range new {
+		key := routeKey(route)
+		if _, ok := oldIndex[key]; !ok && newIndex[key] == i {
+			added = append(added, route)
+		}
+	}
+
+	for i, route := range old {
+		key := routeKey(route)
+		if oldIndex[key] != i {
+			// never matched
+			continue
+		}
+
+		if i, ok := newIndex[key]; ok {
+			changes = append(changes, compareParams(route, new[i])...)
+		} else if i := pair(added, paired, route, shapeKey); i >= 0 {
+			// constrained differently
+			changes = append(changes, compareParams(route, added[i])...)
+		} else {
+			removed = append(removed, route)
+		}
+	}
+
+	for _, route := range removed {
+		change := Change{Kind: Removed, Old: route, Breaking: true}
+
+		if i, ok := newIndex[routeKey(Route{Path: route.Path})]; ok {
+			change.Breaking = false
+			change.Detail = "still served by " + new[i].Path + " for all methods"
+		}
+
+		if i := pair(added, paired, route, pathKey); i >= 0 {
+			candidate := added[i]
+			change.Kind, change.New = MethodChanged, candidate
+			change.Breaking = candidate.Method != ""
+			change.Detail = "now " + candidate.String()
+			if candidate.Method == "" {
+				change.Detail = "now served for all methods"
+			}
+		}
+
+		changes = append(changes, change)
+	}
+
+	for i, route := range added {
+		if !paired[i] {
+			changes = append(changes, Change{Kind: Added, New: route})
+		}
+	}
+
+	return append(changes, shadowed(added, new, oldIndex)...)
+}
+
+// pair returns the index of the first route of added not paired yet with the
+// same key as route, and marks it paired, or -1.
+func pair(added []Route, paired map[int]bool, route Route, key func(Route) string) int {
+	for i, candidate := range added {
+		if !paired[i] && key(candidate) == key(route) {
+			paired[i] = true
+			return i
+		}
+	}
+
+	return -1
+}
+
+// shadowed reports the routes of both tables losing requests to added ones.
+// Routes without method are tried after the routes of the request method, so
+// they only shadow other routes without method.
+func shadowed(added, new []Route, oldIndex map[string]int) []Change {
+	var changes []Change
+
+	for _, route := range added {
+		for _, kept := range new {
+			if _, ok := oldIndex[routeKey(kept)]; !ok {
+				continue
+			}
+
+			if route.Method != kept.Method && kept.Method != "" {
+				continue
+			}
+
+			if shadows(splitSegments(route.Path), splitSegments(kept.Path)) {
+				changes = append(changes, Change{
+					Kind:     Shadowed,
+					Old:      kept,
+					New:      route,
+					Breaking: true,
+					Detail:   "some of its requests now match " + route.String(),
+				})
+			}
+		}
+	}
+
+	return changes
+}
+
+// compareParams reports the changes of the params of a route kept in the new
+// table.
+func compareParams(old, new Route) []Change {
+	var (
+		changes []Change
+		renamed []string
+	)
+
+	oldSegments, newSegments := splitSegments(old.Path), splitSegments(new.Path)
+
+	for i, o := range oldSegments {
+		n := newSegments[i]
+		if o.kind == static {
+			continue
+		}
+
+		if o.name != n.name {
+			renamed = append(renamed, fmt.Sprintf("%c%s is now %c%s", o.prefix(), o.name, n.prefix(), n.name))
+		}
+
+		change := Change{Kind: ConstraintChanged, Old: old, New: new, Breaking: true}
+
+		switch {
+		case o.constraint == n.constraint:
+			continue
+		case o.constraint == "":
+			change.Detail = fmt.Sprintf("adds %s to :%s", n.constraint, n.name)
+		case n.constraint == "":
+			change.Breaking = false
+			change.Detail = fmt.Sprintf("drops %s of :%s", o.constraint, n.name)
+		default:
+			change.Detail = fmt.Sprintf("changes %s of :%s to %s", o.constraint, n.name, n.constraint)
+		}
+
+		changes = append(changes, change)
+	}
+
+	if len(renamed) > 0 {
+		changes = append([]Change{{
+			Kind:   ParamsRenamed,
+			Old:    old,
+			New:    new,
+			Detail: strings.Join(renamed, ", "),
+		}}, changes...)
+	}
+
+	return changes
+}
+
+type segmentKind int
+
+const (
+	static segmentKind = iota
+	constrained
+	param
+	catchAll
+)
+
+// segment is a segment of a path, e.g. "repos", ":id:[0-9]+" or "*path".
+type segment struct {
+	kind       segmentKind
+	text       string
+	name       string
+	constraint string
+}
+
+// rank orders segments as the router tries them, static ones first.
+func (s segment) rank() int {
+	return int(catchAll - s.kind)
+}
+
+func (s segment) prefix() byte {
+	if s.kind == catchAll {
+		return '*'
+	}
+
+	return ':'
+}
+
+func splitSegments(path string) []segment {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	segments := make([]segment, len(parts))
+
+	for i, part := range parts {
+		switch {
+		case strings.HasPrefix(part, ":"):
+			name, constraint, _ := strings.Cut(part[1:], ":")
+			segments[i] = segment{kind: param, name: name, constraint: constraint}
+			if constraint != "" {
+				segments[i].kind = constrained
+			}
+		case strings.HasPrefix(part, "*"):
+			segments[i] = segment{kind: catchAll, name: part[1:]}
+		default:
+			segments[i] = segment{kind: static, text: part}
+		}
+	}
+
+	return segments
+}
+
+// shapeKey identifies the requests of route regardless of the constraints of
+// its params, e.g. "GET /repos/:".
+func shapeKey(route Route) string {
+	return key(route.Method, route.Path, false)
+}
+
+// pathKey is the shapeKey of route for any method, e.g. " /repos/:".
+func pathKey(route Route) string {
+	return key("", route.Path, false)
+}
+
+// routeKey identifies the requests of route, e.g. "GET /repos/:[0-9]+".
+// Constrained and plain params differ, the router tries both.
+func routeKey(route Route) string {
+	return key(route.Method, route.Path, true)
+}
+
+func key(method, path string, constraints bool) string {
+	var b strings.Builder
+	b.WriteString(method + " ")
+
+	for _, s := range splitSegments(path) {
+		b.WriteByte('/')
+
+		switch s.kind {
+		case static:
+			b.WriteString(s.text)
+		case catchAll:
+			b.WriteByte('*')
+		default:
+			b.WriteByte(':')
+			if constraints {
+				b.WriteString(s.constraint)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// indexRoutes maps the keys of routes to the index of their first route.
+func indexRoutes(routes []Route) map[string]int {
+	index := make(map[string]int, len(routes))
+
+	for i := len(routes) - 1; i >= 0; i-- {
+		index[routeKey(routes[i])] = i
+	}
+
+	return index
+}
+
+// shadows tells if the router matches some requests of x to y, since y is
+// tried first at a segment and both match the rest of the path.
+func shadows(y, x []segment) bool {
+	for i := range y {
+		if i >= len(x) {
+			return false
+		}
+
+		switch ry, rx := y[i].rank(), x[i].rank(); {
+		case x[i].kind == catchAll:
+			return ry > rx
+		case ry > rx:
+			return accepts(x[i], y[i]) && overlap(y[i+1:], x[i+1:])
+		case ry < rx || y[i].text != x[i].text || y[i].constraint != x[i].constraint:
+			return false
+		}
+	}
+
+	return false
+}
+
+// overlap tells if some path matches both y and x.
+func overlap(y, x []segment) bool {
+	for i := range y {
+		switch {
+		case y[i].kind == catchAll || i < len(x) && x[i].kind == catchAll:
+			return true
+		case i >= len(x):
+			return false
+		case !accepts(x[i], y[i]) || !accepts(y[i], x[i]):
+			return false
+		}
+	}
+
+	return len(x) == len(y) || x[len(y)].kind == catchAll
+}
+
+// accepts tells if s may match the text of a segment matching other.
+func accepts(s, other segment) bool {
+	switch {
+	case other.kind != static:
+		return true
+	case s.kind == static:
+		return s.text == other.text
+	case s.kind == constrained:
+		re, err := regexp.Compile("^(?:" + s.constraint + ")$")
+		return err != nil || re.MatchString(other.text)
+	}
+
+	return true
+}
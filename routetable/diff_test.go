@@ -0,0 +1,102 @@
+package routetable
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	routes := func(paths ...string) []Route {
+		table := make([]Route, len(paths))
+		for i, path := range paths {
+			table[i] = Route{Path: path}
+			if err := table[i].normalize(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return table
+	}
+
+	for _, tc := range []struct {
+		name     string
+		old, new []Route
+		changes  []string
+		breaking []bool
+	}{
+		{
+			name: "no changes",
+			old:  routes("GET /repos/{owner}", "/health"),
+			new:  routes("/health", "GET /repos/:owner"),
+		},
+		{
+			name:     "removed and added routes",
+			old:      routes("GET /repos", "DELETE /repos/:owner"),
+			new:      routes("GET /repos", "POST /repos"),
+			changes:  []string{"removed DELETE /repos/:owner", "added POST /repos"},
+			breaking: []bool{true, false},
+		},
+		{
+			name:     "method changed",
+			old:      routes("PUT /items/:id", "GET /feeds"),
+			new:      routes("PATCH /items/:id", "/feeds"),
+			changes:  []string{"method changed PUT /items/:id: now PATCH /items/:id", "method changed GET /feeds: now served for all methods"},
+			breaking: []bool{true, false},
+		},
+		{
+			name:     "removed route still served for all methods",
+			old:      routes("GET /feeds", "/feeds"),
+			new:      routes("/feeds"),
+			changes:  []string{"removed GET /feeds: still served by /feeds for all methods"},
+			breaking: []bool{false},
+		},
+		{
+			name:     "renamed params and changed constraints",
+			old:      routes("GET /repos/:owner/:number/*path", "GET /pings/:id:[0-9]+"),
+			new:      routes("GET /repos/:user/:number:[0-9]+/*file", "GET /pings/:id:[a-f0-9]+"),
+			changes:  []string{"params renamed GET /repos/:owner/:number/*path: :owner is now :user, *path is now *file", "constraint changed GET /repos/:owner/:number/*path: adds [0-9]+ to :number", "constraint changed GET /pings/:id:[0-9]+: changes [0-9]+ of :id to [a-f0-9]+"},
+			breaking: []bool{false, true, true},
+		},
+		{
+			name:     "removed route next to a constrained one",
+			old:      routes("GET /items/:id:[0-9]+", "GET /items/:slug"),
+			new:      routes("GET /items/:id:[0-9]+"),
+			changes:  []string{"removed GET /items/:slug"},
+			breaking: []bool{true},
+		},
+		{
+			name: "shadowed routes",
+			old:  routes("GET /users/:id", "GET /files/*path", "/pings/:id", "GET /items/:id:[0-9]+"),
+			new:  routes("GET /users/:id", "GET /users/me", "GET /files/*path", "GET /files/:name/raw", "/pings/:id", "POST /pings/latest", "GET /items/:id:[0-9]+", "GET /items/new", "GET /items/1", "GET /users/:id/raw"),
+			changes: []string{
+				"added GET /users/me", "added GET /files/:name/raw", "added POST /pings/latest", "added GET /items/new", "added GET /items/1", "added GET /users/:id/raw",
+				"shadowed GET /users/:id: some of its requests now match GET /users/me",
+				"shadowed GET /files/*path: some of its requests now match GET /files/:name/raw",
+				"shadowed /pings/:id: some of its requests now match POST /pings/latest",
+				"shadowed GET /items/:id:[0-9]+: some of its requests now match GET /items/1",
+			},
+			breaking: []bool{false, false, false, false, false, false, true, true, true, true},
+		},
+		{
+			name:     "added route for all methods next to routes with method",
+			old:      routes("GET /users/:id"),
+			new:      routes("GET /users/:id", "/users/me"),
+			changes:  []string{"added /users/me"},
+			breaking: []bool{false},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			changes := Diff(tc.old, tc.new)
+
+			if len(changes) != len(tc.changes) {
+				t.Fatalf("\nExpected: %q\nActual:   %v", tc.changes, changes)
+			}
+
+			for i, change := range changes {
+				if change.String() != tc.changes[i] || change.Breaking != tc.breaking[i] {
+					t.Errorf("\nExpected: %s (breaking %v)\nActual:   %s (breaking %v)", tc.changes[i], tc.breaking[i], change, change.Breaking)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package routetable
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// registrations maps the route registering methods of router.Registrar to
+// the positions of their path and method arguments, -1 if the method is
+// implied by the name.
+var registrations = map[string]struct {
+	path, method int
+	implied      string
+}{
+	"Add":          {0, 1, ""},
+	"AddWithError": {0, 1, ""},
+	"Handle":       {1, 0, ""},
+	"HandleFunc":   {1, 0, ""},
+	"AddGet":       {0, -1, http.MethodGet},
+	"AddPost":      {0, -1, http.MethodPost},
+	"AddPut":       {0, -1, http.MethodPut},
+	"AddDelete":    {0, -1, http.MethodDelete},
+	"AddOptions":   {0, -1, http.MethodOptions},
+	"AddPatch":     {0, -1, http.MethodPatch},
+	"AddHead":      {0, -1, http.MethodHead},
+}
+
+// httpMethods are the constants of net/http naming methods.
+var httpMethods = map[string]string{
+	"MethodGet":     http.MethodGet,
+	"MethodHead":    http.MethodHead,
+	"MethodPost":    http.MethodPost,
+	"MethodPut":     http.MethodPut,
+	"MethodPatch":   http.MethodPatch,
+	"MethodDelete":  http.MethodDelete,
+	"MethodConnect": http.MethodConnect,
+	"MethodOptions": http.MethodOptions,
+	"MethodTrace":   http.MethodTrace,
+}
+
+// ParseGo reads the routes Go source registers, through calls such as
+// rtr.Add("/pings/:id", http.MethodGet, ping) or api.AddGet("/repos", list)
+// on a group api := rtr.Group("/api"), and the routes of fixture tables
+// such as {"GET", "/repos/:owner"}. Arguments must be literals or net/http
+// method constants. Handlers given by identifier are taken as handler names.
+func ParseGo(filename string, src []byte) ([]Route, error) {
+	return parseGo(filename, src, "")
+}
+
+// ParseGoTable reads the routes of the fixture table declared as variable
+// table in Go source, e.g. Routes of package fixture.
+func ParseGoTable(filename string, src []byte, table string) ([]Route, error) {
+	return parseGo(filename, src, table)
+}
+
+func parseGo(filename string, src []byte, table string) ([]Route, error) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var root ast.Node = file
+	if table != "" {
+		if root = findVar(file, table); root == nil {
+			return nil, fmt.Errorf("%s: no variable %s", filename, table)
+		}
+	}
+
+	var (
+		routes   []Route
+		prefixes = map[string]string{}
+		errs     []string
+	)
+
+	// prefixOf returns the prefix of the group expr is, e.g. api or
+	// rtr.Group("/api").
+	var prefixOf func(expr ast.Expr) string
+	prefixOf = func(expr ast.Expr) string {
+		switch x := expr.(type) {
+		case *ast.Ident:
+			return prefixes[x.Name]
+		case *ast.CallExpr:
+			receiver, method, ok := selector(x)
+			if ok && method == "Group" && len(x.Args) > 0 {
+				if prefix, ok := stringLit(x.Args[0]); ok {
+					return prefixOf(receiver) + strings.TrimSuffix(prefix, "/")
+				}
+			}
+		}
+
+		return ""
+	}
+
+	add := func(node ast.Node, route Route) {
+		route.File, route.Line = filename, fset.Position(node.Pos()).Line
+
+		if err := route.normalize(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s:%d: %v", filename, route.Line, err))
+			return
+		}
+
+		routes = append(routes, route)
+	}
+
+	ast.Inspect(root, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			// api := rtr.Group("/api")
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+				return true
+			}
+
+			if name, ok := node.Lhs[0].(*ast.Ident); ok {
+				if prefix := prefixOf(node.Rhs[0]); prefix != "" {
+					prefixes[name.Name] = prefix
+				}
+			}
+
+		case *ast.CallExpr:
+			receiver, method, ok := selector(node)
+			registration, known := registrations[method]
+			if !ok || !known || len(node.Args) <= registration.path || len(node.Args) <= registration.method {
+				return true
+			}
+
+			path, ok := stringLit(node.Args[registration.path])
+			if !ok {
+				return true
+			}
+
+			route := Route{Method: registration.implied, Path: prefixOf(receiver) + path}
+
+			if registration.method >= 0 {
+				if route.Method, ok = methodLit(node.Args[registration.method]); !ok {
+					return true
+				}
+			}
+
+			handler := max(registration.path, registration.method) + 1
+			if handler < len(node.Args) {
+				if ident, ok := node.Args[handler].(*ast.Ident); ok {
+					route.Handler = ident.Name
+				}
+			}
+
+			add(node, route)
+
+		case *ast.CompositeLit:
+			// {"GET", "/repos/:owner"} or {Method: "GET", Path: "/repos/:owner"}
+			if route, ok := routeLit(node); ok {
+				add(node, route)
+				return false
+			}
+		}
+
+		return true
+	})
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	return routes, nil
+}
+
+// findVar returns the declaration of the package level variable name.
+func findVar(file *ast.File, name string) ast.Node {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			for _, ident := range spec.(*ast.ValueSpec).Names {
+				if ident.Name == name {
+					return spec
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// selector returns the receiver and name of a method call such as rtr.Add().
+func selector(call *ast.CallExpr) (receiver ast.Expr, method string, ok bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, "", false
+	}
+
+	return sel.X, sel.Sel.Name, true
+}
+
+func routeLit(lit *ast.CompositeLit) (Route, bool) {
+	if len(lit.Elts) != 2 {
+		return Route{}, false
+	}
+
+	var route Route
+
+	for i, elt := range lit.Elts {
+		key := []string{"Method", "Path"}[i]
+
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			ident, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				return Route{}, false
+			}
+
+			key, elt = ident.Name, kv.Value
+		}
+
+		var ok bool
+
+		switch key {
+		case "Method":
+			// other pairs of strings are not taken for routes
+			route.Method, ok = methodLit(elt)
+			ok = ok && knownMethod(route.Method)
+		case "Path":
+			route.Path, ok = stringLit(elt)
+			ok = ok && strings.HasPrefix(route.Path, "/")
+		}
+
+		if !ok {
+			return Route{}, false
+		}
+	}
+
+	return route, route.Method != "" && route.Path != ""
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+
+	s, err := strconv.Unquote(lit.Value)
+
+	return s, err == nil
+}
+
+// methodLit reads an HTTP method given as literal or net/http constant. The
+// empty literal stands for the method of a ServeMux pattern.
+func methodLit(expr ast.Expr) (string, bool) {
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "http" {
+			method, ok := httpMethods[sel.Sel.Name]
+			return method, ok
+		}
+
+		return "", false
+	}
+
+	method, ok := stringLit(expr)
+	if !ok || method != strings.ToUpper(method) {
+		return "", false
+	}
+
+	return method, true
+}
+
+func knownMethod(method string) bool {
+	for _, known := range httpMethods {
+		if method == known {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package routetable
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shyamz-22/router"
+)
+
+// Registry holds the handlers and middleware route tables refer to by name.
+type Registry struct {
+	Handlers   map[string]router.HandlerFuncWithParam
+	Middleware map[string]router.Middleware
+}
+
+// Register adds routes to rtr with the handlers and middleware of registry
+// they name, and sets their Meta:
+//
+//	routes, err := routetable.Load("routes.yaml")
+//	...
+//	err = routetable.Register(rtr, routes, routetable.Registry{
+//		Handlers:   map[string]router.HandlerFuncWithParam{"listPulls": listPulls},
+//		Middleware: map[string]router.Middleware{"auth": auth},
+//	})
+//
+// Nothing is registered if a route names an unknown handler or middleware,
+// has an invalid path or repeats another route. The error lists all of them
+// with the position of the route, e.g.
+//
+//	routes.yaml:7: unknown handler "listPull"
+//
+// Paths are checked by rtr, below its prefix if it is a router.Group. Routes
+// repeat each other if they have the same method and path, whatever the names
+// of their params. A route without method is the fallback of the routes of its
+// path and repeats no route with method. Routes added to rtr before are
+// replaced as by rtr.Add.
+func Register(rtr router.Registrar, routes []Route, registry Registry) error {
+	var (
+		errs []error
+		seen = map[string]Route{}
+	)
+
+	for _, route := range routes {
+		fail := func(format string, args ...interface{}) {
+			err := fmt.Errorf(format, args...)
+			if position := route.Position(); position != "" {
+				err = fmt.Errorf("%s: %w", position, err)
+			}
+			errs = append(errs, err)
+		}
+
+		if route.Handler == "" {
+			fail("route %s has no handler", route)
+		} else if registry.Handlers[route.Handler] == nil {
+			fail("unknown handler %q", route.Handler)
+		}
+
+		for _, name := range route.Middleware {
+			if registry.Middleware[name] == nil {
+				fail("unknown middleware %q", name)
+			}
+		}
+
+		if first, ok := seen[routeKey(route)]; ok {
+			fail("route %s repeats the route of %s", route, first.Position())
+			continue
+		}
+		seen[routeKey(route)] = route
+
+		if err := rtr.Check(route.Path, route.Method); err != nil {
+			fail("%w", err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, route := range routes {
+		middleware := make([]router.Middleware, len(route.Middleware))
+		for i, name := range route.Middleware {
+			middleware[i] = registry.Middleware[name]
+		}
+
+		registered := rtr.Add(route.Path, route.Method, registry.Handlers[route.Handler], middleware...)
+
+		for key, value := range route.Meta {
+			registered.Set(key, value)
+		}
+	}
+
+	return nil
+}
+
+// LoadRoutes loads the route table of the named file and registers its
+// routes with rtr, see Load and Register.
+func LoadRoutes(rtr router.Registrar, filename string, registry Registry) error {
+	routes, err := Load(filename)
+	if err != nil {
+		return err
+	}
+
+	return Register(rtr, routes, registry)
+}
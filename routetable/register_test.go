@@ -0,0 +1,172 @@
+package routetable
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shyamz-22/router"
+	"github.com/shyamz-22/router/assert"
+)
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	registry := Registry{
+		Handlers: map[string]router.HandlerFuncWithParam{
+			"listPulls": func(w http.ResponseWriter, r *http.Request, params router.PathParams) {
+				w.Write([]byte("pulls of " + params.ByName("owner")))
+			},
+			"ping": func(w http.ResponseWriter, r *http.Request, params router.PathParams) {
+				w.Write([]byte("pong"))
+			},
+		},
+		Middleware: map[string]router.Middleware{
+			"outer": tag("outer"),
+			"inner": tag("inner"),
+		},
+	}
+
+	t.Run("registers the routes of a table", func(t *testing.T) {
+		routes, err := Parse("routes.yaml", []byte(`
+- method: GET
+  path: /repos/:owner/pulls
+  handler: listPulls
+  middleware: [outer, inner]
+  meta:
+    summary: List pull requests
+- path: POST /pings
+  handler: ping
+`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rtr := router.New()
+		if err := Register(rtr, routes, registry); err != nil {
+			t.Fatal(err)
+		}
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/repos/gopher/pulls", nil)
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "outer inner pulls of gopher")
+
+		w = httptest.NewRecorder()
+		r, _ = http.NewRequest(http.MethodPost, "/pings", nil)
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "pong")
+
+		route, _ := rtr.Lookup(http.MethodGet, "/repos/gopher/pulls")
+		if route == nil || route.Meta["summary"] != "List pull requests" {
+			t.Errorf("Expected the meta of the table, got %v", route)
+		}
+	})
+
+	t.Run("reports all errors with positions and registers nothing", func(t *testing.T) {
+		routes, err := Parse("routes.yaml", []byte(`
+- path: GET /pings
+  handler: pong
+- path: GET /pulls
+  handler: listPulls
+  middleware: [auth]
+- path: GET /pulls
+  handler: listPulls
+- path: GET /files/*path/raw
+  handler: ping
+- path: GET /pings/:id
+`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rtr := router.New()
+		err = Register(rtr, routes, registry)
+
+		expected := `routes.yaml:2: unknown handler "pong"
+routes.yaml:4: unknown middleware "auth"
+routes.yaml:7: route GET /pulls repeats the route of routes.yaml:4
+routes.yaml:9: Invalid Path: files/*path/raw. Catch-all must be the last segment
+routes.yaml:11: route GET /pings/:id has no handler`
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected: %s\nActual:   %v", expected, err)
+		}
+
+		if len(rtr.Routes()) != 0 {
+			t.Errorf("Expected no routes, got %v", rtr.Routes())
+		}
+	})
+
+	t.Run("takes routes differing in param names only as repeated", func(t *testing.T) {
+		routes, err := Parse("routes.yaml", []byte(`
+- path: GET /items/:id
+  handler: ping
+- path: GET /items/:item
+  handler: ping
+`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = Register(router.New(), routes, registry)
+
+		expected := "routes.yaml:4: route GET /items/:item repeats the route of routes.yaml:2"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected: %s\nActual:   %v", expected, err)
+		}
+	})
+
+	t.Run("registers routes for all methods next to the routes of their path", func(t *testing.T) {
+		routes, err := Parse("routes.yaml", []byte(`
+- path: GET /pings
+  handler: ping
+- path: /pings
+  handler: ping
+`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rtr := router.New()
+		if err := Register(rtr, routes, registry); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(rtr.Routes()) != 2 {
+			t.Errorf("Expected 2 routes, got %v", rtr.Routes())
+		}
+	})
+
+	t.Run("checks paths below the prefix of a group", func(t *testing.T) {
+		routes, err := Parse("routes.yaml", []byte(`
+- path: GET /raw
+  handler: ping
+`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rtr := router.New()
+		err = Register(rtr.Group("/files/*path"), routes, registry)
+
+		expected := "routes.yaml:2: Invalid Path: files/*path/raw. Catch-all must be the last segment"
+		if err == nil || err.Error() != expected {
+			t.Errorf("\nExpected: %s\nActual:   %v", expected, err)
+		}
+
+		if len(rtr.Routes()) != 0 {
+			t.Errorf("Expected no routes, got %v", rtr.Routes())
+		}
+	})
+}
+
+func tag(name string) router.Middleware {
+	return func(route *router.Route, next router.HandlerFuncWithParam) router.HandlerFuncWithParam {
+		return func(w http.ResponseWriter, r *http.Request, params router.PathParams) {
+			w.Write([]byte(name + " "))
+			next(w, r, params)
+		}
+	}
+}
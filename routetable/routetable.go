@@ -0,0 +1,176 @@
+// Package routetable reads route tables, the methods and paths an
+// application serves, for tools such as cmd/routegen and to register them
+// with a router.Router. Tables are read from Go source registering routes or
+// from JSON and YAML files listing them:
+//
+//	# routes.yaml
+//	- method: GET
+//	  path: /repos/:owner/pulls
+//	  handler: listPulls
+//	  middleware: [auth, gzip]
+//	  meta:
+//	    summary: List pull requests
+//
+// Paths may also be net/http.ServeMux patterns, see router.Router.Add.
+package routetable
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shyamz-22/router"
+	"gopkg.in/yaml.v3"
+)
+
+// Route is an entry of a route table.
+type Route struct {
+	// Method of the route, empty if the route serves all methods.
+	Method string `json:"method" yaml:"method"`
+
+	// Path of the route in the router's own syntax.
+	Path string `json:"path" yaml:"path"`
+
+	// Name names the route, e.g. for generated code. Optional.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Handler names the handler of the route in a Registry.
+	Handler string `json:"handler,omitempty" yaml:"handler,omitempty"`
+
+	// Middleware names the middleware of the route in a Registry, outermost
+	// first.
+	Middleware []string `json:"middleware,omitempty" yaml:"middleware,omitempty"`
+
+	// Meta is set as Meta of the registered route.
+	Meta map[string]interface{} `json:"meta,omitempty" yaml:"meta,omitempty"`
+
+	// File and Line tell where the route is defined, Line is 0 if unknown.
+	File string `json:"-" yaml:"-"`
+	Line int    `json:"-" yaml:"-"`
+}
+
+// Load reads the route table of the named file, Go source if it ends in
+// ".go", JSON or YAML otherwise.
+func Load(filename string) ([]Route, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if filepath.Ext(filename) == ".go" {
+		return ParseGo(filename, data)
+	}
+
+	return Parse(filename, data)
+}
+
+// LoadTable reads the fixture table declared as variable table in the Go
+// source of the named file, see ParseGoTable, or the route table of the file
+// if table is empty, see Load.
+func LoadTable(filename, table string) ([]Route, error) {
+	if table == "" {
+		return Load(filename)
+	}
+
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseGoTable(filename, src, table)
+}
+
+// Parse reads a route table in JSON or YAML, a list of routes. This includes
+// router.Router.Routes encoded as JSON. Errors refer to lines of filename.
+func Parse(filename string, data []byte) ([]Route, error) {
+	// JSON is read as YAML to know the lines of the routes
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	list := doc.Content[0]
+	if list.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("%s:%d: expected a list of routes", filename, list.Line)
+	}
+
+	routes := make([]Route, 0, len(list.Content))
+
+	for _, item := range list.Content {
+		lowerKeys(item)
+
+		var route Route
+		if err := item.Decode(&route); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filename, item.Line, err)
+		}
+
+		route.File, route.Line = filename, item.Line
+
+		if err := route.normalize(); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filename, item.Line, err)
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+// lowerKeys lowercases the keys of a mapping, for the fields of encoded
+// router.Route values such as Method.
+func lowerKeys(mapping *yaml.Node) {
+	if mapping.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i < len(mapping.Content); i += 2 {
+		key := mapping.Content[i]
+		key.Value = strings.ToLower(key.Value)
+	}
+}
+
+// normalize translates a ServeMux pattern in Path into method and path.
+func (route *Route) normalize() error {
+	if route.Path == "" {
+		return fmt.Errorf("route has no path")
+	}
+
+	method, path, err := router.ParsePattern(route.Path)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case route.Method == "":
+		route.Method = method
+	case method != "" && method != route.Method:
+		return fmt.Errorf("method %s of pattern %q conflicts with %s", method, route.Path, route.Method)
+	}
+
+	route.Method = strings.ToUpper(route.Method)
+	route.Path = path
+
+	return nil
+}
+
+// Position returns the file and line of route, e.g. "routes.yaml:3".
+func (route Route) Position() string {
+	if route.Line == 0 {
+		return route.File
+	}
+
+	return fmt.Sprintf("%s:%d", route.File, route.Line)
+}
+
+func (route Route) String() string {
+	if route.Method == "" {
+		return route.Path
+	}
+
+	return route.Method + " " + route.Path
+}
@@ -0,0 +1,123 @@
+package routetable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads YAML", func(t *testing.T) {
+		routes, err := Parse("routes.yaml", []byte(`
+- method: GET
+  path: /repos/:owner
+  name: listRepos
+  handler: repos.List
+  middleware: [auth, gzip]
+  meta:
+    summary: List repositories
+    deprecated: true
+- path: POST /items/{id}
+`))
+
+		assertRoutes(t, routes, err, []Route{
+			{
+				Method: "GET", Path: "/repos/:owner", Name: "listRepos", Handler: "repos.List", Middleware: []string{"auth", "gzip"},
+				Meta: map[string]interface{}{"summary": "List repositories", "deprecated": true},
+				File: "routes.yaml", Line: 2,
+			},
+			{Method: "POST", Path: "/items/:id", File: "routes.yaml", Line: 10},
+		})
+	})
+
+	t.Run("reads JSON", func(t *testing.T) {
+		routes, err := Parse("routes.json", []byte(`[
+  {"method": "get", "path": "/pings/:id"}
+]`))
+
+		assertRoutes(t, routes, err, []Route{{Method: "GET", Path: "/pings/:id", File: "routes.json", Line: 2}})
+	})
+
+	t.Run("reports errors with lines", func(t *testing.T) {
+		for data, expected := range map[string]string{
+			"method: GET":                           "routes.yaml:1: expected a list of routes",
+			"- method: GET\n- method: GET\n":        "routes.yaml:1: route has no path",
+			"- method: GET\n  path: /items/id-{id}": "routes.yaml:1: Invalid Pattern: /items/id-{id}. Wildcards must be full segments",
+			"- method: GET\n  path: POST /items":    `routes.yaml:1: method POST of pattern "POST /items" conflicts with GET`,
+		} {
+			if _, err := Parse("routes.yaml", []byte(data)); err == nil || err.Error() != expected {
+				t.Errorf("\nExpected: %s\nActual:   %v", expected, err)
+			}
+		}
+	})
+}
+
+func TestParseGo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads registrations", func(t *testing.T) {
+		routes, err := ParseGo("routes.go", []byte(`package main
+
+func register(rtr *router.Router) {
+	rtr.Add("/pings/:id", http.MethodGet, ping)
+	api := rtr.Group("/api/")
+	api.AddPost("/repos", createRepo).Set("summary", "Create")
+	rtr.Group("/admin").Handle("DELETE", "/cache", flush)
+	rtr.Add("PUT /items/{id}", "", func(w http.ResponseWriter, r *http.Request, params router.PathParams) {})
+	rtr.Add(path, http.MethodGet, ping)
+}
+`))
+
+		assertRoutes(t, routes, err, []Route{
+			{Method: "GET", Path: "/pings/:id", Handler: "ping", File: "routes.go", Line: 4},
+			{Method: "POST", Path: "/api/repos", Handler: "createRepo", File: "routes.go", Line: 6},
+			{Method: "DELETE", Path: "/admin/cache", Handler: "flush", File: "routes.go", Line: 7},
+			{Method: "PUT", Path: "/items/:id", File: "routes.go", Line: 8},
+		})
+	})
+
+	t.Run("reads fixture tables", func(t *testing.T) {
+		routes, err := ParseGo("fixture.go", []byte(`package fixture
+
+var Routes = []Route{
+	{"GET", "/authorizations"},
+	{Method: "DELETE", Path: "/authorizations/:id"},
+	{"X", "/ignored"},
+}
+`))
+
+		assertRoutes(t, routes, err, []Route{
+			{Method: "GET", Path: "/authorizations", File: "fixture.go", Line: 4},
+			{Method: "DELETE", Path: "/authorizations/:id", File: "fixture.go", Line: 5},
+		})
+	})
+
+	t.Run("reads a single fixture table", func(t *testing.T) {
+		src := []byte(`package fixture
+
+var Routes = []Route{{"GET", "/authorizations"}}
+
+var MuxRoutes = []Route{{"GET", "/authorizations/{id}"}}
+`)
+
+		routes, err := ParseGoTable("fixture.go", src, "MuxRoutes")
+		assertRoutes(t, routes, err, []Route{{Method: "GET", Path: "/authorizations/:id", File: "fixture.go", Line: 5}})
+
+		if _, err := ParseGoTable("fixture.go", src, "Missing"); err == nil || err.Error() != "fixture.go: no variable Missing" {
+			t.Errorf("Unexpected error %v", err)
+		}
+	})
+}
+
+func assertRoutes(t *testing.T, routes []Route, err error, expected []Route) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(routes, expected) {
+		t.Errorf("\nExpected: %+v\nActual:   %+v", expected, routes)
+	}
+}
@@ -0,0 +1,140 @@
+package router
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+)
+
+// SPA serves a single page application as NotFound handler of a Router:
+//
+//	rtr.NotFound = &router.SPA{FS: dist, Exclude: []string{"/api"}}
+//
+// Unmatched GET and HEAD requests are answered with the file of FS at the
+// request path, if there is one. Otherwise requests accepting text/html get
+// the Index page, so that deep links reach the client-side routing. Paths
+// below the Exclude prefixes, e.g. those of an API, and all other requests
+// are handed to NotFound.
+type SPA struct {
+	FS fs.FS
+
+	// Index is the page served for client-side routes, "index.html" if empty.
+	Index string
+
+	// Exclude lists path prefixes that never fall back to files or Index.
+	// Prefixes may contain path params, e.g. "/tenants/:tenant/api". They
+	// are parsed once, before the first request; NewSPA reports invalid
+	// ones, which are otherwise compared as they are.
+	Exclude []string
+
+	// NotFound is called for requests without fallback. If nil, a bare 404
+	// is written.
+	NotFound http.Handler
+
+	once     sync.Once
+	files    *FileServer
+	excluded []string
+	err      error
+}
+
+// NewSPA returns an SPA serving fsys that excludes the given path prefixes,
+// or an error if a prefix is not a valid pattern.
+func NewSPA(fsys fs.FS, exclude ...string) (*SPA, error) {
+	spa := &SPA{FS: fsys, Exclude: exclude}
+	if err := spa.init(); err != nil {
+		return nil, err
+	}
+
+	return spa, nil
+}
+
+// init parses Exclude, once.
+func (spa *SPA) init() error {
+	spa.once.Do(func() {
+		spa.files = &FileServer{FS: spa.FS}
+		spa.excluded = make([]string, len(spa.Exclude))
+
+		for i, prefix := range spa.Exclude {
+			_, path, err := ParsePattern(strings.TrimSuffix(prefix, sep))
+			if err != nil {
+				spa.err = errors.Join(spa.err, err)
+				path = prefix
+			}
+
+			spa.excluded[i] = path
+		}
+	})
+
+	return spa.err
+}
+
+func (spa *SPA) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	spa.init()
+
+	if spa.fallback(request) {
+		name := strings.TrimPrefix(path.Clean(request.URL.Path), sep)
+
+		if info, err := fs.Stat(spa.FS, name); err == nil && !info.IsDir() {
+			spa.files.serveContent(w, request, name, info)
+			return
+		}
+
+		if acceptsHTML(request) {
+			spa.serveIndex(w, request)
+			return
+		}
+	}
+
+	if spa.NotFound != nil {
+		spa.NotFound.ServeHTTP(w, request)
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// fallback reports whether request may be answered with files or Index.
+func (spa *SPA) fallback(request *http.Request) bool {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return false
+	}
+
+	for _, prefix := range spa.excluded {
+		if hasPathPrefix(request.URL.Path, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (spa *SPA) serveIndex(w http.ResponseWriter, request *http.Request) {
+	index := spa.Index
+	if index == "" {
+		index = "index.html"
+	}
+
+	info, err := fs.Stat(spa.FS, index)
+	if err != nil {
+		fileError(w, err)
+		return
+	}
+
+	// clients have to revalidate the page, which changes with every release
+	w.Header().Set("Cache-Control", "no-cache")
+	spa.files.serveContent(w, request, index, info)
+}
+
+func acceptsHTML(request *http.Request) bool {
+	for _, mediaRange := range strings.Split(request.Header.Get("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(mediaRange, ";")
+		if strings.TrimSpace(mediaType) == "text/html" {
+			return true
+		}
+	}
+
+	return false
+}
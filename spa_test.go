@@ -0,0 +1,130 @@
+package router
+
+import (
+	"github.com/shyamz-22/router/assert"
+
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRouteWithSPAFallback(t *testing.T) {
+	t.Parallel()
+	rtr := New()
+	rtr.AddGet("/api/users/:id", func(w http.ResponseWriter, r *http.Request, params PathParams) {
+		w.Write([]byte("user " + params.ByName("id")))
+	})
+
+	rtr.NotFound = &SPA{
+		FS: fstest.MapFS{
+			"index.html":    {Data: []byte("<div id=app></div>")},
+			"assets/app.js": {Data: []byte("render()")},
+		},
+		Exclude: []string{"/api"},
+		NotFound: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+		}),
+	}
+
+	t.Run("serves index for deep links", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/users/42/settings", nil)
+		r.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.8")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "<div id=app></div>")
+		assert.ResponseWithHeader(t, w, "Content-Type", "text/html; charset=utf-8")
+		assert.ResponseWithHeader(t, w, "Cache-Control", "no-cache")
+	})
+
+	t.Run("serves existing files", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/assets/app.js", nil)
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "render()")
+	})
+
+	t.Run("does not serve index to other clients", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/assets/missing.js", nil)
+		r.Header.Set("Accept", "*/*")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, `{"error":"not found"}`)
+	})
+
+	t.Run("keeps not found for excluded prefixes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/api/orders", nil)
+		r.Header.Set("Accept", "text/html")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusNotFound, `{"error":"not found"}`)
+	})
+
+	t.Run("keeps method not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodPost, "/api/users/42", nil)
+		r.Header.Set("Accept", "text/html")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusMethodNotAllowed)
+	})
+
+	t.Run("does not fall back for other methods", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodPost, "/users/42", nil)
+		r.Header.Set("Accept", "text/html")
+
+		rtr.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNotFound)
+	})
+}
+
+func TestRouteWithSPAExcludes(t *testing.T) {
+	t.Parallel()
+	files := fstest.MapFS{"index.html": {Data: []byte("<div id=app></div>")}}
+
+	t.Run("reports invalid prefixes when built", func(t *testing.T) {
+		_, err := NewSPA(files, "/api", "/tenants/{}/api")
+		if err == nil || err.Error() != "Invalid Pattern: /tenants/{}/api. Wildcards must be named" {
+			t.Errorf("Unexpected error %v", err)
+		}
+	})
+
+	t.Run("excludes prefixes with params", func(t *testing.T) {
+		spa, err := NewSPA(files, "/tenants/{tenant}/api/")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/tenants/acme/api/users", nil)
+		r.Header.Set("Accept", "text/html")
+
+		spa.ServeHTTP(w, r)
+
+		assert.ResponseWithStatus(t, w, http.StatusNotFound)
+	})
+
+	t.Run("does not panic serving invalid prefixes", func(t *testing.T) {
+		spa := &SPA{FS: files, Exclude: []string{"/tenants/{}/api"}}
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/users/42", nil)
+		r.Header.Set("Accept", "text/html")
+
+		spa.ServeHTTP(w, r)
+
+		assert.ResponseWithBody(t, w, http.StatusOK, "<div id=app></div>")
+	})
+}